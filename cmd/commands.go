@@ -3,6 +3,7 @@ package cmd
 import (
 	"bufio"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
 	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/convert"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/canary"
 	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
 	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/render"
 	"github.com/nikhilsbhat/nginx-traefik-converter/version"
@@ -54,7 +56,15 @@ func getConvertCommand() *cobra.Command {
 				return err
 			}
 
-			var globalReport configs.GlobalReport
+			opts.DisableConverters(cliCfg.DisabledConverters...)
+			opts.ConfigMaps = kubeConfig.GetConfigMapData
+			opts.Secrets = kubeConfig.GetSecretKeys
+			opts.Canaries = canary.Correlate(ingresses)
+
+			var (
+				globalReport configs.GlobalReport
+				strictErrs   []error
+			)
 
 			for _, ingress := range ingresses {
 				res := configs.NewResult()
@@ -69,7 +79,7 @@ func getConvertCommand() *cobra.Command {
 					continue
 				}
 
-				if err = render.WriteYAML(*res, filepath.Join("./out", ingress.Name)); err != nil {
+				if err = render.WriteYAML(*res, filepath.Join("./out", ingress.Name), opts.SingleFile); err != nil {
 					logger.Error("writing converted traefik ingress errored",
 						slog.Any("ingress", ingress.Name),
 						slog.Any("error:", err.Error()))
@@ -85,12 +95,20 @@ func getConvertCommand() *cobra.Command {
 					globalReport.Ingresses,
 					ctx.Result.IngressReport,
 				)
+
+				if strictErr := ctx.Result.Err(); strictErr != nil {
+					strictErrs = append(strictErrs, strictErr)
+				}
 			}
 
 			if err = printerConfig.PrintGlobalSummary(globalReport); err != nil {
 				return err
 			}
 
+			if len(strictErrs) > 0 {
+				return errors.Join(strictErrs...)
+			}
+
 			logger.Info("nginx ingress to traefik conversion completed")
 
 			return nil
@@ -11,11 +11,12 @@ import (
 
 // Config holds the information of the cli config.
 type Config struct {
-	NoColor     bool
-	LogLevel    string
-	IngressFile string
-	ToFile      string
-	Files       []string
+	NoColor            bool
+	LogLevel           string
+	IngressFile        string
+	ToFile             string
+	Files              []string
+	DisabledConverters []string
 }
 
 var (
@@ -53,4 +54,16 @@ func registerImportFlags(cmd *cobra.Command) {
 		"when enabled won't consider the plugins while creating middlewares")
 	cmd.PersistentFlags().BoolVarP(&opts.ProxyBufferHeuristic, "proxy-buffer-heuristic", "", false,
 		"when enabled, the nginx ingress annotation 'proxy-buffer-size' gets heuristically mapped to Traefik buffering")
+	cmd.PersistentFlags().BoolVarP(&opts.SingleFile, "single-file", "", false,
+		"when enabled all generated traefik objects are written to a single file instead of one file per kind")
+	cmd.PersistentFlags().BoolVarP(&opts.Strict, "strict", "", false,
+		"when enabled the conversion fails if any annotation was skipped or ignored")
+	cmd.PersistentFlags().StringSliceVarP(&cliCfg.DisabledConverters, "disable-converter", "", nil,
+		"names of the converters that should not run, for example 'cors' (can be repeated or comma separated)")
+	cmd.PersistentFlags().BoolVarP(&opts.SuggestYAML, "suggest-yaml", "", false,
+		"when enabled warnings about settings that need traefik static configuration include a YAML snippet")
+	cmd.PersistentFlags().BoolVarP(&opts.PluginPlaceholders, "plugin-placeholders", "", false,
+		"when enabled configuration-snippet sub_filter directives are scaffolded as a rewritebody plugin placeholder middleware")
+	cmd.PersistentFlags().StringVarP(&opts.GlobalAuthURL, "global-auth-url", "", "",
+		"address of the global external auth (the nginx 'global-auth-url' setting) applied to every ingress as ForwardAuth")
 }
@@ -0,0 +1,20 @@
+package configs
+
+// Canary is a path of a canary Ingress, paired with the same host and path of
+// its primary Ingress. ingress-nginx serves the canary backend from the upstream
+// of the primary Ingress, so its routing is generated together with the primary.
+type Canary struct {
+	// Ingress is the namespace/name of the canary Ingress.
+	Ingress string `yaml:"ingress,omitempty"     json:"ingress,omitempty"`
+
+	// Path is the host/path/backend tuple of the canary Ingress.
+	Path IngressPath `yaml:"path,omitempty"        json:"path,omitempty"`
+
+	// Annotations are the annotations of the canary Ingress.
+	Annotations map[string]string `yaml:"annotations,omitempty" json:"annotations,omitempty"`
+}
+
+// CanariesOf returns the canaries paired with the primary Ingress namespace/name.
+func (o *Options) CanariesOf(namespace, name string) []Canary {
+	return o.Canaries[namespace+"/"+name]
+}
@@ -2,11 +2,54 @@ package configs
 
 // Options holds the options required to run the converters.
 type Options struct {
-	ProxyBufferHeuristic bool `yaml:"proxy_buffer_heuristic,omitempty" json:"proxy_buffer_heuristic,omitempty"`
-	DisablePlugins       bool `yaml:"disable_plugins,omitempty"        json:"disable_plugins,omitempty"`
+	ProxyBufferHeuristic bool            `yaml:"proxy_buffer_heuristic,omitempty" json:"proxy_buffer_heuristic,omitempty"`
+	DisablePlugins       bool            `yaml:"disable_plugins,omitempty"        json:"disable_plugins,omitempty"`
+	SingleFile           bool            `yaml:"single_file,omitempty"            json:"single_file,omitempty"`
+	Strict               bool            `yaml:"strict,omitempty"                 json:"strict,omitempty"`
+	PluginPlaceholders   bool            `yaml:"plugin_placeholders,omitempty"    json:"plugin_placeholders,omitempty"`
+	Converters           map[string]bool `yaml:"converters,omitempty"             json:"converters,omitempty"`
+	SuggestYAML          bool            `yaml:"suggest_yaml,omitempty"           json:"suggest_yaml,omitempty"`
+	GlobalAuthURL        string          `yaml:"global_auth_url,omitempty"        json:"global_auth_url,omitempty"`
+	ConfigMaps           ConfigMapLookup `yaml:"-"                                json:"-"`
+	Secrets              SecretLookup    `yaml:"-"                                json:"-"`
+	Canaries             CanaryPairs     `yaml:"-"                                json:"-"`
 }
 
+// ConfigMapLookup returns the data of the ConfigMap with the given name from the namespace.
+type ConfigMapLookup func(namespace, name string) (map[string]string, error)
+
+// SecretLookup returns the data keys of the Secret with the given name from the namespace.
+// Only the keys are exposed, so that secret material never reaches the converters.
+type SecretLookup func(namespace, name string) ([]string, error)
+
+// CanaryPairs holds the canaries of every primary Ingress, keyed by the namespace/name
+// of the primary Ingress. It is computed across all Ingresses before they are converted.
+type CanaryPairs map[string][]Canary
+
 // NewOptions returns new instance of Options when invoked.
 func NewOptions() *Options {
 	return &Options{}
 }
+
+// ConverterEnabled reports whether the converter with the given name should run.
+// Converters are enabled unless they are explicitly disabled in Converters.
+func (o *Options) ConverterEnabled(name string) bool {
+	enabled, ok := o.Converters[name]
+
+	return !ok || enabled
+}
+
+// DisableConverters disables the converters with the given names.
+func (o *Options) DisableConverters(names ...string) {
+	if len(names) == 0 {
+		return
+	}
+
+	if o.Converters == nil {
+		o.Converters = make(map[string]bool, len(names))
+	}
+
+	for _, name := range names {
+		o.Converters[name] = false
+	}
+}
@@ -0,0 +1,86 @@
+package configs
+
+import (
+	netv1 "k8s.io/api/networking/v1"
+)
+
+// IngressPath is a single host/path/backend tuple of an Ingress. Every tuple
+// becomes one router of the generated IngressRoute, sharing the middlewares
+// generated from the Ingress annotations.
+type IngressPath struct {
+	// Host is the rule host, empty when the rule matches all hosts.
+	Host string `yaml:"host,omitempty"      json:"host,omitempty"`
+
+	// Path is the HTTP path of the rule.
+	Path string `yaml:"path,omitempty"      json:"path,omitempty"`
+
+	// PathType is the path type, ImplementationSpecific when not set.
+	PathType netv1.PathType `yaml:"path_type,omitempty" json:"path_type,omitempty"`
+
+	// Backend is the Kubernetes service serving the path.
+	Backend *netv1.IngressServiceBackend `yaml:"backend,omitempty"   json:"backend,omitempty"`
+}
+
+// Paths flattens the Ingress rules into host/path/backend tuples, in rule and
+// path order. Paths without a service backend are left out.
+func (ctx *Context) Paths() []IngressPath {
+	if ctx.Ingress == nil {
+		return nil
+	}
+
+	paths := make([]IngressPath, 0)
+
+	for _, rule := range ctx.Ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+
+		for _, path := range rule.HTTP.Paths {
+			if path.Backend.Service == nil {
+				continue
+			}
+
+			pathType := netv1.PathTypeImplementationSpecific
+			if path.PathType != nil {
+				pathType = *path.PathType
+			}
+
+			paths = append(paths, IngressPath{
+				Host:     rule.Host,
+				Path:     path.Path,
+				PathType: pathType,
+				Backend:  path.Backend.Service,
+			})
+		}
+	}
+
+	return paths
+}
+
+// defaultBackendPort is assumed for a default-backend Service no backend of the
+// Ingress routes to, it is the port of the ingress-nginx default backend.
+const defaultBackendPort = 80
+
+// DefaultBackendService returns the backend of the Service named by the default-backend
+// annotation. ingress-nginx looks the Service up in the namespace of the Ingress and
+// serves its first port, which the converter cannot read; the port is taken from a
+// backend of the Ingress routing to the same Service, found is false when none does
+// and port 80 is assumed.
+func (ctx *Context) DefaultBackendService(name string) (*netv1.IngressServiceBackend, bool) {
+	if ctx.Ingress != nil && ctx.Ingress.Spec.DefaultBackend != nil {
+		if svc := ctx.Ingress.Spec.DefaultBackend.Service; svc != nil && svc.Name == name {
+			return svc, true
+		}
+	}
+
+	for _, path := range ctx.Paths() {
+		if path.Backend.Name == name {
+			return path.Backend, true
+		}
+	}
+
+	return &netv1.IngressServiceBackend{
+		Name: name,
+		Port: netv1.ServiceBackendPort{Number: defaultBackendPort},
+	}, false
+}
@@ -29,6 +29,10 @@ const (
 	// intentionally ignored because it is not applicable or has no effect
 	// in Traefik.
 	AnnotationIgnored AnnotationStatus = "ignored"
+
+	// AnnotationError indicates that the annotation was skipped or ignored
+	// while strict mode was enabled, and therefore fails the conversion.
+	AnnotationError AnnotationStatus = "error"
 )
 
 // AnnotationReportEntry represents the migration result of a single
@@ -77,7 +81,18 @@ func (ctx *Context) StartIngressReport(ns, name string) {
 
 // addReport appends a new annotation report entry to the current Ingress report.
 // It is an internal helper used by the public Report* methods.
+// In strict mode skipped and ignored annotations are escalated to errors.
 func (ctx *Context) addReport(name string, status AnnotationStatus, msg string) {
+	if ctx.Options != nil && ctx.Options.Strict &&
+		(status == AnnotationSkipped || status == AnnotationIgnored) {
+		status = AnnotationError
+	}
+
+	ctx.appendReport(name, status, msg)
+}
+
+// appendReport appends the entry as is, without any strict mode escalation.
+func (ctx *Context) appendReport(name string, status AnnotationStatus, msg string) {
 	ctx.Result.IngressReport.Entries = append(
 		ctx.Result.IngressReport.Entries,
 		AnnotationReportEntry{
@@ -112,3 +127,10 @@ func (ctx *Context) ReportWarning(name, msg string) {
 func (ctx *Context) ReportIgnored(name string, msg string) {
 	ctx.addReport(name, AnnotationIgnored, msg)
 }
+
+// ReportDefault records that the given annotation was ignored because its value
+// is already the Traefik default, so nothing is lost by dropping it.
+// Unlike ReportIgnored it is not escalated to an error in strict mode.
+func (ctx *Context) ReportDefault(name string, msg string) {
+	ctx.appendReport(name, AnnotationIgnored, msg)
+}
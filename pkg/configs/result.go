@@ -1,21 +1,127 @@
 package configs
 
 import (
+	"fmt"
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/errors"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	traefik "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // Result holds the translated configs for a nginx ingress.
 type Result struct {
-	Middlewares   []*traefik.Middleware   `yaml:"middlewares,omitempty"     json:"middlewares,omitempty"`
-	IngressRoutes []*traefik.IngressRoute `yaml:"ingress_routes,omitempty"  json:"ingress_routes,omitempty"`
-	TLSOptions    []*traefik.TLSOption    `yaml:"tls_options,omitempty"     json:"tls_options,omitempty"`
-	TLSOptionRefs map[string]string       `yaml:"tls_option_refs,omitempty" json:"tls_option_refs,omitempty"`
-	Warnings      []string                `yaml:"warnings,omitempty"        json:"warnings,omitempty"`
-	IngressReport IngressReport           `yaml:"ingress_report,omitempty"  json:"ingress_report,omitempty"`
+	Middlewares          []*traefik.Middleware          `yaml:"middlewares,omitempty"            json:"middlewares,omitempty"`
+	IngressRoutes        []*traefik.IngressRoute        `yaml:"ingress_routes,omitempty"         json:"ingress_routes,omitempty"`
+	TLSOptions           []*traefik.TLSOption           `yaml:"tls_options,omitempty"            json:"tls_options,omitempty"`
+	TLSOptionRefs        map[string]string              `yaml:"tls_option_refs,omitempty"        json:"tls_option_refs,omitempty"`
+	ServersTransports    []*traefik.ServersTransport    `yaml:"servers_transports,omitempty"     json:"servers_transports,omitempty"`
+	ServersTransportRefs map[string]string              `yaml:"servers_transport_refs,omitempty" json:"servers_transport_refs,omitempty"`
+	LoadBalancers        map[string]*LoadBalancer       `yaml:"load_balancers,omitempty"         json:"load_balancers,omitempty"`
+	Mirrors              map[string]*traefik.Mirroring  `yaml:"mirrors,omitempty"                json:"mirrors,omitempty"`
+	TraefikServices      []*traefik.TraefikService      `yaml:"traefik_services,omitempty"       json:"traefik_services,omitempty"`
+	RateLimitExemptions  map[string]*RateLimitExemption `yaml:"rate_limit_exemptions,omitempty"  json:"rate_limit_exemptions,omitempty"`
+	Jobs                 []*batchv1.Job                 `yaml:"jobs,omitempty"                   json:"jobs,omitempty"`
+	ServiceAccounts      []*corev1.ServiceAccount       `yaml:"service_accounts,omitempty"       json:"service_accounts,omitempty"`
+	Roles                []*rbacv1.Role                 `yaml:"roles,omitempty"                  json:"roles,omitempty"`
+	RoleBindings         []*rbacv1.RoleBinding          `yaml:"role_bindings,omitempty"          json:"role_bindings,omitempty"`
+	Warnings             []string                       `yaml:"warnings,omitempty"               json:"warnings,omitempty"`
+	Notes                []string                       `yaml:"notes,omitempty"                  json:"notes,omitempty"`
+	IngressReport        IngressReport                  `yaml:"ingress_report,omitempty"         json:"ingress_report,omitempty"`
 	// Report        GlobalReport      `yaml:"report,omitempty"         json:"report,omitempty"`
 }
 
+// LoadBalancer holds the load balancing settings applied to every service of an
+// IngressRoute, in the ingress-nginx upstreams these are configured per Ingress.
+type LoadBalancer struct {
+	// Sticky defines the sticky sessions of the services.
+	Sticky *dynamic.Sticky `yaml:"sticky,omitempty"   json:"sticky,omitempty"`
+
+	// Strategy defines the load balancing strategy between the servers.
+	Strategy dynamic.BalancerStrategy `yaml:"strategy,omitempty" json:"strategy,omitempty"`
+}
+
+// RateLimitExemption holds the sources ingress-nginx exempts from the rate limits of
+// an Ingress, Traefik serves them with companion routes without the middlewares.
+type RateLimitExemption struct {
+	// SourceRange are the exempted IPs and CIDRs.
+	SourceRange []string `yaml:"source_range,omitempty" json:"source_range,omitempty"`
+
+	// Middlewares are the names of the RateLimit middlewares the sources skip.
+	Middlewares []string `yaml:"middlewares,omitempty"  json:"middlewares,omitempty"`
+}
+
+// KindObjects holds all generated objects of a single kind.
+type KindObjects struct {
+	// Kind is the kind of the objects, for example "Middleware".
+	Kind string `yaml:"kind,omitempty"    json:"kind,omitempty"`
+
+	// Objects are the generated objects of this kind.
+	Objects []client.Object `yaml:"objects,omitempty" json:"objects,omitempty"`
+}
+
 // NewResult returns new instance of Result.
 func NewResult() *Result {
 	return &Result{}
 }
+
+// ObjectsByKind returns the generated objects grouped by kind. Kinds without
+// any object are left out and the order of the groups is stable, so writers
+// can rely on it for deterministic output.
+func (r *Result) ObjectsByKind() []KindObjects {
+	groups := []KindObjects{
+		{Kind: "Middleware", Objects: toClientObjects(r.Middlewares)},
+		{Kind: "IngressRoute", Objects: toClientObjects(r.IngressRoutes)},
+		{Kind: "TLSOption", Objects: toClientObjects(r.TLSOptions)},
+		{Kind: "ServersTransport", Objects: toClientObjects(r.ServersTransports)},
+		{Kind: "TraefikService", Objects: toClientObjects(r.TraefikServices)},
+		{Kind: "ServiceAccount", Objects: toClientObjects(r.ServiceAccounts)},
+		{Kind: "Role", Objects: toClientObjects(r.Roles)},
+		{Kind: "RoleBinding", Objects: toClientObjects(r.RoleBindings)},
+		{Kind: "Job", Objects: toClientObjects(r.Jobs)},
+	}
+
+	out := make([]KindObjects, 0, len(groups))
+
+	for _, group := range groups {
+		if len(group.Objects) > 0 {
+			out = append(out, group)
+		}
+	}
+
+	return out
+}
+
+// Err returns an error listing the annotations which were escalated to errors
+// in strict mode, or nil when every annotation was handled.
+func (r *Result) Err() error {
+	failed := make([]string, 0)
+
+	for _, entry := range r.IngressReport.Entries {
+		if entry.Status == AnnotationError {
+			failed = append(failed, entry.Name)
+		}
+	}
+
+	if len(failed) == 0 {
+		return nil
+	}
+
+	return &errors.ConverterError{Message: fmt.Sprintf(
+		"strict mode: %d annotation(s) of ingress %s/%s were not converted: %s",
+		len(failed), r.IngressReport.Namespace, r.IngressReport.Name, strings.Join(failed, ", "),
+	)}
+}
+
+func toClientObjects[T client.Object](in []T) []client.Object {
+	out := make([]client.Object, 0, len(in))
+	for _, o := range in {
+		out = append(out, o)
+	}
+
+	return out
+}
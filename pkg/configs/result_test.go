@@ -0,0 +1,81 @@
+package configs_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	traefik "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResult_ObjectsByKind(t *testing.T) {
+	t.Run("should group generated objects by kind", func(t *testing.T) {
+		result := configs.NewResult()
+		result.Middlewares = []*traefik.Middleware{
+			{ObjectMeta: metav1.ObjectMeta{Name: "test-cors"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "test-rewrite"}},
+		}
+		result.TLSOptions = []*traefik.TLSOption{
+			{ObjectMeta: metav1.ObjectMeta{Name: "test-mtls"}},
+		}
+
+		groups := result.ObjectsByKind()
+
+		if len(groups) != 2 {
+			t.Fatalf("expected 2 groups, got %d", len(groups))
+		}
+
+		if groups[0].Kind != "Middleware" || len(groups[0].Objects) != 2 {
+			t.Errorf("expected 2 Middleware objects first, got %s with %d objects", groups[0].Kind, len(groups[0].Objects))
+		}
+
+		if groups[1].Kind != "TLSOption" || groups[1].Objects[0].GetName() != "test-mtls" {
+			t.Errorf("expected the TLSOption group second, got %s", groups[1].Kind)
+		}
+	})
+}
+
+func TestResult_Err(t *testing.T) {
+	t.Run("should escalate ignored annotations to errors in strict mode", func(t *testing.T) {
+		ctx := newTestContext()
+		ctx.Options.Strict = true
+
+		ctx.ReportConverted("a-annotation")
+		ctx.ReportIgnored("b-annotation", "ignored")
+
+		if ctx.Result.IngressReport.Entries[1].Status != configs.AnnotationError {
+			t.Errorf("expected ignored annotation to be escalated, got %s", ctx.Result.IngressReport.Entries[1].Status)
+		}
+
+		err := ctx.Result.Err()
+		if err == nil || !strings.Contains(err.Error(), "b-annotation") {
+			t.Errorf("expected strict mode error naming b-annotation, got %v", err)
+		}
+	})
+
+	t.Run("should not fail on ignored annotations by default", func(t *testing.T) {
+		ctx := newTestContext()
+
+		ctx.ReportIgnored("b-annotation", "ignored")
+
+		if err := ctx.Result.Err(); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+
+	t.Run("should not escalate annotations matching the Traefik defaults in strict mode", func(t *testing.T) {
+		ctx := newTestContext()
+		ctx.Options.Strict = true
+
+		ctx.ReportDefault("b-annotation", "default")
+
+		if ctx.Result.IngressReport.Entries[0].Status != configs.AnnotationIgnored {
+			t.Errorf("expected default annotation to stay ignored, got %s", ctx.Result.IngressReport.Entries[0].Status)
+		}
+
+		if err := ctx.Result.Err(); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,78 @@
+package configs
+
+import (
+	"sort"
+)
+
+// severityOrder lists the non-converted annotation statuses from the most to
+// the least severe. It drives the ordering of WarningSummary.
+var severityOrder = []AnnotationStatus{
+	AnnotationError,
+	AnnotationSkipped,
+	AnnotationWarned,
+	AnnotationIgnored,
+}
+
+// AnnotationMessages holds all report messages recorded for a single annotation.
+type AnnotationMessages struct {
+	// Annotation is the full annotation key.
+	Annotation string `yaml:"annotation,omitempty" json:"annotation,omitempty"`
+
+	// Messages are the report messages in the order they were recorded.
+	Messages []string `yaml:"messages,omitempty"   json:"messages,omitempty"`
+}
+
+// SeverityGroup groups the report entries sharing a status by annotation.
+type SeverityGroup struct {
+	// Status is the severity shared by all entries of the group.
+	Status AnnotationStatus `yaml:"status,omitempty"      json:"status,omitempty"`
+
+	// Count is the total number of report entries in the group.
+	Count int `yaml:"count,omitempty"       json:"count,omitempty"`
+
+	// Annotations holds the entries grouped by annotation, sorted by name.
+	Annotations []AnnotationMessages `yaml:"annotations,omitempty" json:"annotations,omitempty"`
+}
+
+// WarningSummary groups the diagnostics of the Ingress report by severity and
+// originating annotation. Converted entries are left out. Groups are ordered
+// from the most to the least severe and annotations are sorted by name, so the
+// output is deterministic for a given report.
+func (r *Result) WarningSummary() []SeverityGroup {
+	groups := make([]SeverityGroup, 0, len(severityOrder))
+
+	for _, status := range severityOrder {
+		byAnnotation := make(map[string][]string)
+		count := 0
+
+		for _, entry := range r.IngressReport.Entries {
+			if entry.Status != status {
+				continue
+			}
+
+			byAnnotation[entry.Name] = append(byAnnotation[entry.Name], entry.Message)
+			count++
+		}
+
+		if count == 0 {
+			continue
+		}
+
+		annotations := make([]AnnotationMessages, 0, len(byAnnotation))
+		for name, messages := range byAnnotation {
+			annotations = append(annotations, AnnotationMessages{Annotation: name, Messages: messages})
+		}
+
+		sort.SliceStable(annotations, func(i, j int) bool {
+			return annotations[i].Annotation < annotations[j].Annotation
+		})
+
+		groups = append(groups, SeverityGroup{
+			Status:      status,
+			Count:       count,
+			Annotations: annotations,
+		})
+	}
+
+	return groups
+}
@@ -0,0 +1,65 @@
+package configs_test
+
+import (
+	"io"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestContext() *configs.Context {
+	ingress := &netv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+	}
+
+	ctx := configs.New(ingress, configs.NewResult(), configs.NewOptions(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx.StartIngressReport(ingress.Namespace, ingress.Name)
+
+	return ctx
+}
+
+func TestResult_WarningSummary(t *testing.T) {
+	t.Run("should group diagnostics by severity and annotation", func(t *testing.T) {
+		ctx := newTestContext()
+
+		ctx.ReportWarning("b-annotation", "second warning")
+		ctx.ReportConverted("c-annotation")
+		ctx.ReportIgnored("c-annotation", "ignored")
+		ctx.ReportWarning("a-annotation", "first warning")
+		ctx.ReportSkipped("b-annotation", "skipped")
+		ctx.ReportWarning("b-annotation", "third warning")
+
+		expected := []configs.SeverityGroup{
+			{
+				Status: configs.AnnotationSkipped,
+				Count:  1,
+				Annotations: []configs.AnnotationMessages{
+					{Annotation: "b-annotation", Messages: []string{"skipped"}},
+				},
+			},
+			{
+				Status: configs.AnnotationWarned,
+				Count:  3,
+				Annotations: []configs.AnnotationMessages{
+					{Annotation: "a-annotation", Messages: []string{"first warning"}},
+					{Annotation: "b-annotation", Messages: []string{"second warning", "third warning"}},
+				},
+			},
+			{
+				Status: configs.AnnotationIgnored,
+				Count:  1,
+				Annotations: []configs.AnnotationMessages{
+					{Annotation: "c-annotation", Messages: []string{"ignored"}},
+				},
+			},
+		}
+
+		if actual := ctx.Result.WarningSummary(); !reflect.DeepEqual(expected, actual) {
+			t.Errorf("unexpected summary\nexpected: %+v\nactual:   %+v", expected, actual)
+		}
+	})
+}
@@ -0,0 +1,186 @@
+package convert_test
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/convert"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/canary"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newIngress(name, service string, annotations map[string]string) netv1.Ingress {
+	return netv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Annotations: annotations},
+		Spec: netv1.IngressSpec{
+			Rules: []netv1.IngressRule{{
+				Host: "example.com",
+				IngressRuleValue: netv1.IngressRuleValue{HTTP: &netv1.HTTPIngressRuleValue{
+					Paths: []netv1.HTTPIngressPath{{
+						Path: "/",
+						Backend: netv1.IngressBackend{Service: &netv1.IngressServiceBackend{
+							Name: service,
+							Port: netv1.ServiceBackendPort{Number: 80},
+						}},
+					}},
+				}},
+			}},
+		},
+	}
+}
+
+func runIngress(t *testing.T, ingress netv1.Ingress, opts *configs.Options) *configs.Context {
+	t.Helper()
+
+	ctx := configs.New(&ingress, configs.NewResult(), opts, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx.StartIngressReport(ingress.Namespace, ingress.Name)
+
+	if err := convert.Run(*ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return ctx
+}
+
+func TestRun_canary(t *testing.T) {
+	primary := newIngress("app", "app", nil)
+	canaryIngress := newIngress("app-canary", "app-v2", map[string]string{
+		string(models.Canary):       "true",
+		string(models.CanaryWeight): "20",
+	})
+
+	opts := configs.NewOptions()
+	opts.Canaries = canary.Correlate([]netv1.Ingress{primary, canaryIngress})
+
+	t.Run("should split the primary route with a weighted TraefikService", func(t *testing.T) {
+		ctx := runIngress(t, primary, opts)
+
+		if len(ctx.Result.TraefikServices) != 1 {
+			t.Fatalf("expected a TraefikService, got %d", len(ctx.Result.TraefikServices))
+		}
+
+		services := ctx.Result.TraefikServices[0].Spec.Weighted.Services
+		if len(services) != 2 {
+			t.Fatalf("expected two weighted services, got %d", len(services))
+		}
+
+		if services[0].Name != "app" || *services[0].Weight != 80 || services[1].Name != "app-v2" || *services[1].Weight != 20 {
+			t.Errorf("expected app=80 and app-v2=20, got %s=%d and %s=%d",
+				services[0].Name, *services[0].Weight, services[1].Name, *services[1].Weight)
+		}
+
+		if len(ctx.Result.IngressRoutes) != 1 {
+			t.Fatalf("expected an IngressRoute, got %d", len(ctx.Result.IngressRoutes))
+		}
+
+		ref := ctx.Result.IngressRoutes[0].Spec.Routes[0].Services[0]
+		if ref.Kind != "TraefikService" || ref.Name != "app-canary" {
+			t.Errorf("expected the route to reference TraefikService app-canary, got %s %s", ref.Kind, ref.Name)
+		}
+	})
+
+	t.Run("should split the weights by canary-weight-total", func(t *testing.T) {
+		fineGrained := newIngress("app-canary", "app-v2", map[string]string{
+			string(models.Canary):            "true",
+			string(models.CanaryWeight):      "15",
+			string(models.CanaryWeightTotal): "1000",
+		})
+
+		options := configs.NewOptions()
+		options.Canaries = canary.Correlate([]netv1.Ingress{primary, fineGrained})
+
+		ctx := runIngress(t, primary, options)
+
+		services := ctx.Result.TraefikServices[0].Spec.Weighted.Services
+		if *services[0].Weight != 985 || *services[1].Weight != 15 {
+			t.Errorf("expected weights 985 and 15, got %d and %d", *services[0].Weight, *services[1].Weight)
+		}
+	})
+
+	t.Run("should not route the canary Ingress on its own", func(t *testing.T) {
+		ctx := runIngress(t, canaryIngress, opts)
+
+		if len(ctx.Result.IngressRoutes) != 0 || len(ctx.Result.TraefikServices) != 0 {
+			t.Errorf("expected no routing for the canary Ingress")
+		}
+
+		if len(ctx.Result.Notes) != 1 {
+			t.Errorf("expected a note about the primary Ingress, got %v", ctx.Result.Notes)
+		}
+	})
+
+	t.Run("should warn about canaries without a primary Ingress", func(t *testing.T) {
+		ctx := runIngress(t, canaryIngress, configs.NewOptions())
+
+		if len(ctx.Result.Warnings) != 1 {
+			t.Errorf("expected a missing primary warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
+
+func TestRun_canaryMatchers(t *testing.T) {
+	primary := newIngress("app", "app", nil)
+	canaryIngress := newIngress("app-canary", "app-v2", map[string]string{
+		string(models.Canary):              "true",
+		string(models.CanaryByHeader):      "X-Canary",
+		string(models.CanaryByHeaderValue): "beta",
+		string(models.CanaryByCookie):      "canary",
+	})
+
+	opts := configs.NewOptions()
+	opts.Canaries = canary.Correlate([]netv1.Ingress{primary, canaryIngress})
+
+	t.Run("should route header and cookie canaries with the priority of the weighted route", func(t *testing.T) {
+		ctx := runIngress(t, primary, opts)
+
+		routes := ctx.Result.IngressRoutes[0].Spec.Routes
+		if len(routes) != 4 {
+			t.Fatalf("expected the weighted, header and two cookie routes, got %d", len(routes))
+		}
+
+		base := "Host(`example.com`) && PathPrefix(`/`)"
+		header := "Header(`X-Canary`, `beta`)"
+		always := "HeaderRegexp(`Cookie`, `(^|;\\s*)canary=always(;|$)`)"
+		never := "HeaderRegexp(`Cookie`, `(^|;\\s*)canary=never(;|$)`)"
+
+		// the routes are exclusive and share the default priority of the path route, so
+		// that a sibling path with a longer rule keeps outranking them.
+		expected := []struct {
+			match    string
+			service  string
+			priority int
+		}{
+			{base + " && !" + header + " && !" + always + " && !" + never, "app-canary", len(base)},
+			{base + " && " + header, "app-v2", len(base)},
+			{base + " && !" + header + " && " + always, "app-v2", len(base)},
+			{base + " && !" + header + " && !" + always + " && " + never, "app", len(base)},
+		}
+
+		for index, want := range expected {
+			route := routes[index]
+			if route.Match != want.match || route.Services[0].Name != want.service || route.Priority != want.priority {
+				t.Errorf("expected route %q to %s with priority %d, got %q to %s with priority %d",
+					want.match, want.service, want.priority, route.Match, route.Services[0].Name, route.Priority)
+			}
+		}
+	})
+
+	t.Run("should ignore the header pattern when a header value is set", func(t *testing.T) {
+		annotations := map[string]string{
+			string(models.Canary):                "true",
+			string(models.CanaryByHeader):        "X-Canary",
+			string(models.CanaryByHeaderValue):   "beta",
+			string(models.CanaryByHeaderPattern): "^b.*",
+		}
+
+		ctx := runIngress(t, newIngress("app-canary", "app-v2", annotations), opts)
+
+		if len(ctx.Result.Warnings) != 0 {
+			t.Errorf("expected no warnings, got %v", ctx.Result.Warnings)
+		}
+	})
+}
@@ -12,7 +12,7 @@ type middlewareCategory int
 const (
 	catShortCircuit     middlewareCategory = iota // A: return-status plugin (future)
 	catResponseHeaders                            // B: CORS, headers, cookie rewrites, upstream-vhost
-	catAuth                                       // C: BasicAuth, ForwardAuth
+	catAuth                                       // C: BasicAuth, DigestAuth, ForwardAuth, IPAllowList
 	catRequestTransform                           // D: rewrite, redirect, bodysize, proxy-redirect
 	catOther                                      // E: fallback
 )
@@ -35,7 +35,9 @@ func classifyMiddleware(middleware *traefik.Middleware) middlewareCategory {
 
 	// C: auth
 	case middleware.Spec.BasicAuth != nil,
-		middleware.Spec.ForwardAuth != nil:
+		middleware.Spec.DigestAuth != nil,
+		middleware.Spec.ForwardAuth != nil,
+		middleware.Spec.IPAllowList != nil:
 		return catAuth
 
 	// D: request transformers
@@ -1,62 +1,71 @@
 package convert
 
 import (
+	"fmt"
+
 	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/canary"
 	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/ingressroute"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/loadbalancer"
 	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/mirror"
 	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/tls"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/transport"
 )
 
 // Run processes ingress annotations using the available converters.
 // It is the core function responsible for converting NGINX Ingress
 // annotations into their Traefik equivalents.
 func Run(ctx configs.Context) error {
-	if err := middleware.CORS(ctx); err != nil {
-		return err
-	}
+	// canary Ingresses are routed together with their primary Ingress.
+	if canary.IsCanary(ctx.Annotations) {
+		canary.HandleCanaryIngress(ctx)
 
-	if err := middleware.ProxyCookiePath(ctx); err != nil {
-		return err
+		return nil
 	}
 
-	middleware.UpstreamVHost(ctx)
-	middleware.BasicAuth(ctx)
+	for _, converter := range Converters() {
+		if !ctx.Options.ConverterEnabled(converter.Name) {
+			note := fmt.Sprintf("converter %s is disabled, its annotations were not converted", converter.Name)
 
-	if err := middleware.BodySize(ctx); err != nil {
-		return err
-	}
+			ctx.Log.Info(note)
+			ctx.Result.Notes = append(ctx.Result.Notes, note)
 
-	middleware.RewriteTargets(ctx)
-	middleware.SSLRedirect(ctx)
+			continue
+		}
 
-	if err := middleware.RateLimit(ctx); err != nil {
-		return err
+		if err := converter.Convert(ctx); err != nil {
+			return err
+		}
 	}
 
-	if err := middleware.ProxyRedirect(ctx); err != nil {
-		return err
-	}
+	middleware.MergeErrors(ctx)
 
-	if err := middleware.ConfigurationSnippets(ctx); err != nil {
-		return err
-	}
+	sortMiddlewares(ctx.Result.Middlewares)
+
+	// the TLSOption, ServersTransport, load balancing and mirroring settings have to exist
+	// before the IngressRoute referencing them is built.
+	tls.HandleAuthTLSVerifyClient(ctx)
 
-	middleware.ProxyBufferSizes(ctx) // 👈 heuristic-aware
-	middleware.ServerSnippet(ctx)
-	middleware.EnableUnderscoresInHeaders(ctx)
-	middleware.ExtraAnnotations(ctx)
-	middleware.ProxyBuffering(ctx)
-	middleware.HandleAuthURL(ctx)
+	transport.HandleProxyHTTPVersion(ctx)
 
-	sortMiddlewares(ctx.Result.Middlewares)
+	transport.HandleProxySSL(ctx)
+
+	transport.HandleUpstreamKeepalive(ctx)
+
+	loadbalancer.HandleAffinity(ctx)
+
+	loadbalancer.HandleUpstreamHashBy(ctx)
 
-	if ingressroute.NeedsIngressRoute(ctx.Annotations) {
+	loadbalancer.HandleLoadBalance(ctx)
+
+	mirror.HandleMirrorTarget(ctx)
+
+	if ingressroute.NeedsIngressRoute(ctx) {
 		if err := ingressroute.BuildIngressRoute(ctx); err != nil {
 			ctx.Result.Warnings = append(ctx.Result.Warnings, err.Error())
 		}
 	}
 
-	tls.HandleAuthTLSVerifyClient(ctx)
-
 	return nil
 }
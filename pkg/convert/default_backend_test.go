@@ -0,0 +1,95 @@
+package convert_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/convert"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func hasWarning(ctx *configs.Context, substr string) bool {
+	for _, warning := range ctx.Result.Warnings {
+		if strings.Contains(warning, substr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestRun_defaultBackend(t *testing.T) {
+	t.Run("should route unmatched requests to the default-backend with a catch-all router", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{string(models.DefaultBackend): "fallback"})
+		withBackend(ctx)
+
+		if err := convert.Run(*ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.Middlewares) != 0 {
+			t.Errorf("expected no Errors middleware, got %d middlewares", len(ctx.Result.Middlewares))
+		}
+
+		if len(ctx.Result.IngressRoutes) != 1 {
+			t.Fatalf("expected an IngressRoute, got %d", len(ctx.Result.IngressRoutes))
+		}
+
+		routes := ctx.Result.IngressRoutes[0].Spec.Routes
+		if len(routes) != 2 {
+			t.Fatalf("expected the path and catch-all routes, got %d", len(routes))
+		}
+
+		catchAll := routes[1]
+		if catchAll.Match != "Host(`example.com`) && PathPrefix(`/`)" || catchAll.Priority != 1 {
+			t.Errorf("expected a lowest priority catch-all of example.com, got %q with priority %d", catchAll.Match, catchAll.Priority)
+		}
+
+		if service := catchAll.Services[0]; service.Name != "fallback" || service.Namespace != "" || service.Port.IntValue() != 80 {
+			t.Errorf("expected the service fallback of the Ingress namespace on port 80, got %+v", service.LoadBalancerSpec)
+		}
+
+		if !hasWarning(ctx, "port 80 was assumed") {
+			t.Errorf("expected assumed port warning, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should take the port of the default-backend from the Ingress backend of the service", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{string(models.DefaultBackend): "app"})
+		withBackend(ctx)
+
+		if err := convert.Run(*ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		catchAll := ctx.Result.IngressRoutes[0].Spec.Routes[1]
+		if service := catchAll.Services[0]; service.Name != "app" || service.Port.IntValue() != 443 {
+			t.Errorf("expected the service app on port 443, got %+v", service.LoadBalancerSpec)
+		}
+
+		if hasWarning(ctx, "was assumed") {
+			t.Errorf("expected no assumed port warning, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should serve the error pages from the default-backend with custom-http-errors", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.DefaultBackend):   "fallback",
+			string(models.CustomHTTPErrors): "404,503",
+		})
+		withBackend(ctx)
+
+		if err := convert.Run(*ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.Middlewares) != 1 || ctx.Result.Middlewares[0].Spec.Errors == nil {
+			t.Fatalf("expected an Errors middleware, got %d middlewares", len(ctx.Result.Middlewares))
+		}
+
+		if routes := ctx.Result.IngressRoutes[0].Spec.Routes; len(routes) != 1 {
+			t.Errorf("expected no catch-all route, got %d routes", len(routes))
+		}
+	})
+}
@@ -0,0 +1,192 @@
+package convert_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/convert"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+func TestRun_upstreamHashBy(t *testing.T) {
+	t.Run("should pin clients hashed by IP with a sticky cookie", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{string(models.UpstreamHashBy): "$remote_addr"})
+		withBackend(ctx)
+
+		if err := convert.Run(*ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.IngressRoutes) != 1 {
+			t.Fatalf("expected an IngressRoute, got %d", len(ctx.Result.IngressRoutes))
+		}
+
+		sticky := ctx.Result.IngressRoutes[0].Spec.Routes[0].Services[0].Sticky
+		if sticky == nil || sticky.Cookie == nil || sticky.Cookie.Name != "test-sticky" {
+			t.Fatalf("expected the sticky cookie test-sticky, got %+v", sticky)
+		}
+	})
+
+	t.Run("should warn about hash keys without an equivalent", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.UpstreamHashBy):       "$request_uri",
+			string(models.UpstreamHashBySubset): "true",
+		})
+		withBackend(ctx)
+
+		if err := convert.Run(*ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.IngressRoutes) != 0 {
+			t.Errorf("expected no IngressRoute, got %d", len(ctx.Result.IngressRoutes))
+		}
+
+		warnings := strings.Join(ctx.Result.Warnings, "\n")
+
+		if !strings.Contains(warnings, "consistent hashing load balancer plugin keyed on $request_uri") {
+			t.Errorf("expected a plugin recommendation, got %v", ctx.Result.Warnings)
+		}
+
+		if !strings.Contains(warnings, "upstream-hash-by-subset cannot be converted") {
+			t.Errorf("expected a subset warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
+
+func TestRun_loadBalance(t *testing.T) {
+	tests := []struct {
+		name      string
+		algorithm string
+		want      dynamic.BalancerStrategy
+	}{
+		{name: "should map ewma to the leasttime strategy", algorithm: "ewma", want: dynamic.BalancerStrategyLeastTime},
+		{name: "should map ip_hash to the hrw strategy", algorithm: "ip_hash", want: dynamic.BalancerStrategyHRW},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := newTestContext(map[string]string{string(models.LoadBalance): tt.algorithm})
+			withBackend(ctx)
+
+			if err := convert.Run(*ctx); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(ctx.Result.IngressRoutes) != 1 {
+				t.Fatalf("expected an IngressRoute, got %d", len(ctx.Result.IngressRoutes))
+			}
+
+			if strategy := ctx.Result.IngressRoutes[0].Spec.Routes[0].Services[0].Strategy; strategy != tt.want {
+				t.Errorf("expected strategy %s, got %s", tt.want, strategy)
+			}
+		})
+	}
+
+	t.Run("should keep the default for round_robin", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{string(models.LoadBalance): "round_robin"})
+		withBackend(ctx)
+
+		if err := convert.Run(*ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.IngressRoutes) != 0 || len(ctx.Result.Warnings) != 0 {
+			t.Errorf("expected neither an IngressRoute nor warnings, got %d and %v",
+				len(ctx.Result.IngressRoutes), ctx.Result.Warnings)
+		}
+	})
+}
+
+func TestRun_affinity(t *testing.T) {
+	t.Run("should configure the sticky cookie of the IngressRoute services", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.Affinity):              "cookie",
+			string(models.SessionCookieName):     "route",
+			string(models.SessionCookiePath):     "/app",
+			string(models.SessionCookieExpires):  "3600",
+			string(models.SessionCookieMaxAge):   "172800",
+			string(models.SessionCookieSecure):   "true",
+			string(models.SessionCookieSameSite): "Strict",
+			string(models.UpstreamHashBy):        "$remote_addr",
+		})
+		withBackend(ctx)
+
+		if err := convert.Run(*ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.IngressRoutes) != 1 {
+			t.Fatalf("expected an IngressRoute, got %d", len(ctx.Result.IngressRoutes))
+		}
+
+		sticky := ctx.Result.IngressRoutes[0].Spec.Routes[0].Services[0].Sticky
+		if sticky == nil || sticky.Cookie == nil {
+			t.Fatalf("expected a sticky cookie, got %+v", sticky)
+		}
+
+		want := dynamic.Cookie{Name: "route", HTTPOnly: true, Secure: true, SameSite: "strict", MaxAge: 172800}
+		got := *sticky.Cookie
+		got.Path = nil
+
+		if got != want {
+			t.Errorf("expected cookie %+v, got %+v", want, got)
+		}
+
+		if sticky.Cookie.Path == nil || *sticky.Cookie.Path != "/app" {
+			t.Errorf("expected cookie path /app, got %v", sticky.Cookie.Path)
+		}
+
+		if len(ctx.Result.Warnings) != 0 {
+			t.Errorf("expected no warnings, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should use the ingress-nginx cookie name by default", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{string(models.Affinity): "cookie"})
+		withBackend(ctx)
+
+		if err := convert.Run(*ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if name := ctx.Result.IngressRoutes[0].Spec.Routes[0].Services[0].Sticky.Cookie.Name; name != "INGRESSCOOKIE" {
+			t.Errorf("expected cookie INGRESSCOOKIE, got %q", name)
+		}
+	})
+}
+
+func TestRun_affinityMode(t *testing.T) {
+	tests := []struct {
+		name        string
+		mode        string
+		wantWarning bool
+	}{
+		{name: "should keep persistent sessions on the sticky cookie", mode: "persistent"},
+		{name: "should warn that balanced sessions are not rebalanced", mode: "balanced", wantWarning: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := newTestContext(map[string]string{
+				string(models.Affinity):     "cookie",
+				string(models.AffinityMode): tt.mode,
+			})
+			withBackend(ctx)
+
+			if err := convert.Run(*ctx); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(ctx.Result.IngressRoutes) != 1 || ctx.Result.IngressRoutes[0].Spec.Routes[0].Services[0].Sticky == nil {
+				t.Fatalf("expected a sticky IngressRoute service")
+			}
+
+			warned := strings.Contains(strings.Join(ctx.Result.Warnings, "\n"), "does not rebalance sticky sessions")
+			if warned != tt.wantWarning {
+				t.Errorf("expected rebalance warning %t, got %v", tt.wantWarning, ctx.Result.Warnings)
+			}
+		})
+	}
+}
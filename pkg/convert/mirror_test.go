@@ -0,0 +1,102 @@
+package convert_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/convert"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestRun_mirrorTarget(t *testing.T) {
+	t.Run("should route the requests through a mirroring TraefikService", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.MirrorTarget): "https://mirror.shadow.svc.cluster.local:8443$request_uri",
+		})
+		withBackend(ctx)
+
+		if err := convert.Run(*ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.TraefikServices) != 1 {
+			t.Fatalf("expected a TraefikService, got %d", len(ctx.Result.TraefikServices))
+		}
+
+		mirroring := ctx.Result.TraefikServices[0].Spec.Mirroring
+		if mirroring == nil || mirroring.Name != "app" || len(mirroring.Mirrors) != 1 {
+			t.Fatalf("expected mirroring of service app, got %+v", mirroring)
+		}
+
+		mirror := mirroring.Mirrors[0]
+		if mirror.Name != "mirror" || mirror.Namespace != "shadow" || mirror.Port.IntValue() != 8443 ||
+			mirror.Scheme != "https" || mirror.Percent != 100 {
+			t.Errorf("expected the https mirror shadow/mirror:8443, got %+v", mirror)
+		}
+
+		ref := ctx.Result.IngressRoutes[0].Spec.Routes[0].Services[0]
+		if ref.Kind != "TraefikService" || ref.Name != "test-mirror" {
+			t.Errorf("expected the route to reference TraefikService test-mirror, got %s %s", ref.Kind, ref.Name)
+		}
+
+		if !strings.Contains(strings.Join(ctx.Result.Warnings, "\n"), "allowCrossNamespace") {
+			t.Errorf("expected a cross namespace warning, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should warn about URIs and hosts Traefik cannot reproduce", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.MirrorTarget): "http://mirror/shadow$request_uri",
+			string(models.MirrorHost):   "shadow.example.com",
+		})
+		withBackend(ctx)
+
+		if err := convert.Run(*ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		warnings := strings.Join(ctx.Result.Warnings, "\n")
+
+		if !strings.Contains(warnings, `mirror-target URI "/shadow$request_uri" cannot be reproduced`) {
+			t.Errorf("expected a URI warning, got %v", ctx.Result.Warnings)
+		}
+
+		if !strings.Contains(warnings, `mirror-host "shadow.example.com" cannot be configured`) {
+			t.Errorf("expected a mirror-host warning, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should stop mirroring the request body when mirror-request-body is off", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.MirrorTarget):      "http://mirror$request_uri",
+			string(models.MirrorRequestBody): "off",
+		})
+		withBackend(ctx)
+
+		if err := convert.Run(*ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		mirroring := ctx.Result.TraefikServices[0].Spec.Mirroring
+		if mirroring.MirrorBody == nil || *mirroring.MirrorBody {
+			t.Errorf("expected mirrorBody false, got %v", mirroring.MirrorBody)
+		}
+
+		if mirroring.MaxBodySize != nil {
+			t.Errorf("expected no maxBodySize, got %d", *mirroring.MaxBodySize)
+		}
+	})
+
+	t.Run("should skip mirrors outside of the cluster", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{string(models.MirrorTarget): "https://test.env.com$request_uri"})
+		withBackend(ctx)
+
+		if err := convert.Run(*ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.TraefikServices) != 0 || len(ctx.Result.IngressRoutes) != 0 {
+			t.Errorf("expected no mirroring, got %d TraefikServices", len(ctx.Result.TraefikServices))
+		}
+	})
+}
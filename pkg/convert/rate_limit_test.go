@@ -0,0 +1,48 @@
+package convert_test
+
+import (
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/convert"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestRun_limitWhitelist(t *testing.T) {
+	t.Run("should serve the limit-whitelist sources by a route without the rate limit", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.LimitRPS):       "10",
+			string(models.LimitWhitelist): "10.0.0.0/8",
+			string(models.EnableCORS):     "true",
+		})
+		withBackend(ctx)
+
+		if err := convert.Run(*ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		routes := ctx.Result.IngressRoutes[0].Spec.Routes
+		if len(routes) != 2 {
+			t.Fatalf("expected the limited and the exempted routes, got %d", len(routes))
+		}
+
+		base := "Host(`example.com`) && PathPrefix(`/`)"
+		limited, exempted := routes[0], routes[1]
+
+		if limited.Match != base+" && !(ClientIP(`10.0.0.0/8`))" || limited.Priority != len(base) {
+			t.Errorf("expected the limited route to exclude the sources, got %q with priority %d", limited.Match, limited.Priority)
+		}
+
+		if exempted.Match != base+" && (ClientIP(`10.0.0.0/8`))" || exempted.Priority != len(base) {
+			t.Errorf("expected the exempted route to match the sources, got %q with priority %d", exempted.Match, exempted.Priority)
+		}
+
+		if len(limited.Middlewares) != 2 || len(exempted.Middlewares) != 1 || exempted.Middlewares[0].Name != "test-cors" {
+			t.Errorf("expected the exempted route to only skip the rate limit, got %v and %v", limited.Middlewares, exempted.Middlewares)
+		}
+
+		if status := reportStatus(ctx, models.LimitWhitelist); status != configs.AnnotationConverted {
+			t.Errorf("expected limit-whitelist to be converted, got %q", status)
+		}
+	})
+}
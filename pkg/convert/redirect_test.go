@@ -0,0 +1,61 @@
+package convert_test
+
+import (
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/convert"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+	netv1 "k8s.io/api/networking/v1"
+)
+
+func TestRun_permanentRedirect(t *testing.T) {
+	t.Run("should attach the permanent redirect to every route of the ingress", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.PermanentRedirect):     "https://example.com/new",
+			string(models.PermanentRedirectCode): "308",
+		})
+
+		paths := make([]netv1.HTTPIngressPath, 0)
+		for _, path := range []string{"/api", "/web"} {
+			paths = append(paths, netv1.HTTPIngressPath{
+				Path: path,
+				Backend: netv1.IngressBackend{Service: &netv1.IngressServiceBackend{
+					Name: "app",
+					Port: netv1.ServiceBackendPort{Number: 80},
+				}},
+			})
+		}
+
+		ctx.Ingress.Spec.Rules = []netv1.IngressRule{{
+			Host:             "example.com",
+			IngressRuleValue: netv1.IngressRuleValue{HTTP: &netv1.HTTPIngressRuleValue{Paths: paths}},
+		}}
+
+		if err := convert.Run(*ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.IngressRoutes) != 1 {
+			t.Fatalf("expected an IngressRoute, got %d", len(ctx.Result.IngressRoutes))
+		}
+
+		routes := ctx.Result.IngressRoutes[0].Spec.Routes
+		if len(routes) != 2 {
+			t.Fatalf("expected two routes, got %d", len(routes))
+		}
+
+		for _, route := range routes {
+			found := false
+
+			for _, ref := range route.Middlewares {
+				if ref.Name == "test-permanent-redirect" {
+					found = true
+				}
+			}
+
+			if !found {
+				t.Errorf("expected route %s to reference the permanent redirect, got %v", route.Match, route.Middlewares)
+			}
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package convert
+
+import (
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+)
+
+// Converter is a named annotation converter run by Run.
+type Converter struct {
+	// Name identifies the converter in Options.Converters, for example "cors".
+	Name string
+	// Convert runs the converter against the ingress of the context.
+	Convert func(ctx configs.Context) error
+}
+
+// Converters returns the middleware converters in the order Run executes them.
+func Converters() []Converter {
+	return []Converter{
+		{Name: "cors", Convert: middleware.CORS},
+		{Name: "proxy-cookie-path", Convert: middleware.ProxyCookiePath},
+		{Name: "upstream-vhost", Convert: noError(middleware.UpstreamVHost)},
+		{Name: "connection-proxy-header", Convert: noError(middleware.ConnectionProxyHeader)},
+		{Name: "basic-auth", Convert: noError(middleware.BasicAuth)},
+		{Name: "digest-auth", Convert: noError(middleware.DigestAuth)},
+		{Name: "whitelist-source-range", Convert: noError(middleware.WhitelistSourceRange)},
+		{Name: "body-size", Convert: middleware.BodySize},
+		{Name: "rewrite-target", Convert: noError(middleware.RewriteTargets)},
+		{Name: "ssl-redirect", Convert: noError(middleware.SSLRedirect)},
+		{Name: "preserve-trailing-slash", Convert: noError(middleware.PreserveTrailingSlash)},
+		{Name: "use-port-in-redirects", Convert: noError(middleware.UsePortInRedirects)},
+		{Name: "permanent-redirect", Convert: noError(middleware.PermanentRedirect)},
+		{Name: "temporal-redirect", Convert: noError(middleware.TemporalRedirect)},
+		{Name: "app-root", Convert: noError(middleware.AppRoot)},
+		{Name: "rate-limit", Convert: middleware.RateLimit},
+		{Name: "global-rate-limit", Convert: noError(middleware.GlobalRateLimit)},
+		{Name: "proxy-redirect", Convert: middleware.ProxyRedirect},
+		{Name: "configuration-snippet", Convert: middleware.ConfigurationSnippets},
+		{Name: "proxy-buffer-size", Convert: noError(middleware.ProxyBufferSizes)},
+		{Name: "server-snippet", Convert: noError(middleware.ServerSnippet)},
+		{Name: "enable-underscores-in-headers", Convert: noError(middleware.EnableUnderscoresInHeaders)},
+		{Name: "extra-annotations", Convert: noError(middleware.ExtraAnnotations)},
+		{Name: "proxy-buffering", Convert: noError(middleware.ProxyBuffering)},
+		{Name: "proxy-timeouts", Convert: noError(middleware.ProxyTimeouts)},
+		{Name: "proxy-next-upstream", Convert: noError(middleware.ProxyNextUpstream)},
+		{Name: "auth-url", Convert: noError(middleware.HandleAuthURL)},
+		{Name: "global-auth", Convert: noError(middleware.GlobalAuth)},
+		{Name: "auth-tls-pass-certificate", Convert: noError(middleware.PassTLSClientCert)},
+		{Name: "satisfy", Convert: noError(middleware.Satisfy)},
+		{Name: "custom-http-errors", Convert: noError(middleware.CustomHTTPErrors)},
+	}
+}
+
+func noError(convert func(ctx configs.Context)) func(ctx configs.Context) error {
+	return func(ctx configs.Context) error {
+		convert(ctx)
+
+		return nil
+	}
+}
@@ -0,0 +1,120 @@
+package convert_test
+
+import (
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/convert"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestContext(annotations map[string]string) *configs.Context {
+	ingress := &netv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test",
+			Namespace:   "default",
+			Annotations: annotations,
+		},
+	}
+
+	ctx := configs.New(ingress, configs.NewResult(), configs.NewOptions(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx.StartIngressReport(ingress.Namespace, ingress.Name)
+
+	return ctx
+}
+
+func TestRun_disabledConverters(t *testing.T) {
+	annotations := map[string]string{
+		string(models.EnableCORS):      "true",
+		string(models.CorsAllowOrigin): "https://example.com",
+	}
+
+	t.Run("should skip a disabled converter and note it", func(t *testing.T) {
+		ctx := newTestContext(annotations)
+		ctx.Options.DisableConverters("cors")
+
+		if err := convert.Run(*ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.Middlewares) != 0 {
+			t.Errorf("expected no middlewares, got %d", len(ctx.Result.Middlewares))
+		}
+
+		if len(ctx.Result.Notes) != 1 || !strings.Contains(ctx.Result.Notes[0], "converter cors is disabled") {
+			t.Errorf("expected a note about the disabled converter, got %v", ctx.Result.Notes)
+		}
+	})
+
+	t.Run("should run all converters by default", func(t *testing.T) {
+		ctx := newTestContext(annotations)
+
+		if err := convert.Run(*ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.Middlewares) == 0 {
+			t.Error("expected the cors converter to generate a middleware")
+		}
+
+		if len(ctx.Result.Notes) != 0 {
+			t.Errorf("expected no notes, got %v", ctx.Result.Notes)
+		}
+	})
+}
+
+func TestRun_strict(t *testing.T) {
+	t.Run("should not fail an ingress without annotations in strict mode", func(t *testing.T) {
+		ctx := newTestContext(nil)
+		ctx.Options.Strict = true
+
+		if err := convert.Run(*ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := ctx.Result.Err(); err != nil {
+			t.Errorf("expected no strict mode error, got %v", err)
+		}
+	})
+
+	t.Run("should not fail on annotations matching the Traefik defaults", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.Satisfy):            "all",
+			string(models.ProxyBodySize):      "0",
+			string(models.UsePortInRedirects): "false",
+			string(models.EnableGlobalAuth):   "true",
+			string(models.EnableCORS):         "false",
+			string(models.ProxyBuffering):     "off",
+			string(models.AppRoot):            "/",
+		})
+		ctx.Options.Strict = true
+
+		if err := convert.Run(*ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := ctx.Result.Err(); err != nil {
+			t.Errorf("expected no strict mode error, got %v", err)
+		}
+	})
+
+	t.Run("should fail on configuration-snippet directives which were dropped", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): "add_header X-Custom value;\nlua_code_cache off;",
+		})
+		ctx.Options.Strict = true
+
+		if err := convert.Run(*ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := ctx.Result.Err(); err == nil || !strings.Contains(err.Error(), string(models.ConfigurationSnippet)) {
+			t.Errorf("expected a strict mode error for the configuration-snippet, got %v", err)
+		}
+	})
+}
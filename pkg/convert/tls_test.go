@@ -0,0 +1,133 @@
+package convert_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/convert"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+	netv1 "k8s.io/api/networking/v1"
+)
+
+func TestRun_authTLS(t *testing.T) {
+	t.Run("should reference the client auth TLSOption from the IngressRoute", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.AuthTLSVerifyClient): "on",
+			string(models.AuthTLSSecret):       "default/ca-secret",
+			string(models.AuthTLSVerifyDepth):  "2",
+		})
+
+		ctx.Ingress.Spec = netv1.IngressSpec{
+			TLS: []netv1.IngressTLS{{Hosts: []string{"example.com"}, SecretName: "example-tls"}},
+			Rules: []netv1.IngressRule{{
+				Host: "example.com",
+				IngressRuleValue: netv1.IngressRuleValue{HTTP: &netv1.HTTPIngressRuleValue{
+					Paths: []netv1.HTTPIngressPath{{
+						Path: "/",
+						Backend: netv1.IngressBackend{Service: &netv1.IngressServiceBackend{
+							Name: "app",
+							Port: netv1.ServiceBackendPort{Number: 80},
+						}},
+					}},
+				}},
+			}},
+		}
+
+		if err := convert.Run(*ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.TLSOptions) != 1 {
+			t.Fatalf("expected a TLSOption, got %d", len(ctx.Result.TLSOptions))
+		}
+
+		clientAuth := ctx.Result.TLSOptions[0].Spec.ClientAuth
+		if clientAuth.ClientAuthType != "RequireAndVerifyClientCert" || clientAuth.SecretNames[0] != "ca-secret" {
+			t.Errorf("unexpected clientAuth %+v", clientAuth)
+		}
+
+		if len(ctx.Result.IngressRoutes) != 1 {
+			t.Fatalf("expected an IngressRoute, got %d", len(ctx.Result.IngressRoutes))
+		}
+
+		spec := ctx.Result.IngressRoutes[0].Spec
+		if spec.TLS == nil || spec.TLS.Options == nil || spec.TLS.Options.Name != "test-mtls" {
+			t.Fatalf("expected the IngressRoute to reference the TLSOption, got %+v", spec.TLS)
+		}
+
+		if spec.TLS.SecretName != "example-tls" || !slices.Equal(spec.EntryPoints, []string{"websecure"}) {
+			t.Errorf("expected the ingress certificate on websecure only, got %q on %v", spec.TLS.SecretName, spec.EntryPoints)
+		}
+
+		found := false
+
+		for _, warning := range ctx.Result.Warnings {
+			if strings.Contains(warning, `auth-tls-verify-depth "2" cannot be configured`) {
+				found = true
+			}
+		}
+
+		if !found {
+			t.Errorf("expected a verify depth warning, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should serve the routes of every TLS entry with its certificate", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.AuthTLSVerifyClient): "on",
+			string(models.AuthTLSSecret):       "ca-secret",
+		})
+
+		rule := func(host string) netv1.IngressRule {
+			return netv1.IngressRule{
+				Host: host,
+				IngressRuleValue: netv1.IngressRuleValue{HTTP: &netv1.HTTPIngressRuleValue{
+					Paths: []netv1.HTTPIngressPath{{
+						Path: "/",
+						Backend: netv1.IngressBackend{Service: &netv1.IngressServiceBackend{
+							Name: "app",
+							Port: netv1.ServiceBackendPort{Number: 80},
+						}},
+					}},
+				}},
+			}
+		}
+
+		ctx.Ingress.Spec = netv1.IngressSpec{
+			TLS: []netv1.IngressTLS{
+				{Hosts: []string{"example.com"}, SecretName: "example-tls"},
+				{Hosts: []string{"example.org"}, SecretName: "example-org-tls"},
+			},
+			Rules: []netv1.IngressRule{rule("example.com"), rule("example.org")},
+		}
+
+		if err := convert.Run(*ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.IngressRoutes) != 2 {
+			t.Fatalf("expected an IngressRoute per certificate, got %d", len(ctx.Result.IngressRoutes))
+		}
+
+		for index, expected := range []struct{ name, secret, match string }{
+			{"test", "example-tls", "Host(`example.com`)"},
+			{"test-example-org-tls", "example-org-tls", "Host(`example.org`)"},
+		} {
+			ingressRoute := ctx.Result.IngressRoutes[index]
+			if ingressRoute.Name != expected.name || ingressRoute.Spec.TLS.SecretName != expected.secret {
+				t.Errorf("expected IngressRoute %s with %s, got %s with %s",
+					expected.name, expected.secret, ingressRoute.Name, ingressRoute.Spec.TLS.SecretName)
+			}
+
+			routes := ingressRoute.Spec.Routes
+			if len(routes) != 1 || !strings.HasPrefix(routes[0].Match, expected.match) {
+				t.Errorf("expected the %s route on %s, got %+v", expected.match, expected.name, routes)
+			}
+
+			if ingressRoute.Spec.TLS.Options.Name != "test-mtls" || !slices.Equal(ingressRoute.Spec.EntryPoints, []string{"websecure"}) {
+				t.Errorf("expected the TLSOption on websecure only for %s, got %+v", expected.name, ingressRoute.Spec)
+			}
+		}
+	})
+}
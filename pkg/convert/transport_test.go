@@ -0,0 +1,250 @@
+package convert_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/convert"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+	netv1 "k8s.io/api/networking/v1"
+)
+
+func withBackend(ctx *configs.Context) {
+	ctx.Ingress.Spec = netv1.IngressSpec{
+		Rules: []netv1.IngressRule{{
+			Host: "example.com",
+			IngressRuleValue: netv1.IngressRuleValue{HTTP: &netv1.HTTPIngressRuleValue{
+				Paths: []netv1.HTTPIngressPath{{
+					Path: "/",
+					Backend: netv1.IngressBackend{Service: &netv1.IngressServiceBackend{
+						Name: "app",
+						Port: netv1.ServiceBackendPort{Number: 443},
+					}},
+				}},
+			}},
+		}},
+	}
+}
+
+// reportStatus returns the status the annotation was reported with, empty when it was not reported.
+func reportStatus(ctx *configs.Context, annotation models.Annotation) configs.AnnotationStatus {
+	for _, entry := range ctx.Result.IngressReport.Entries {
+		if entry.Name == string(annotation) {
+			return entry.Status
+		}
+	}
+
+	return ""
+}
+
+func TestRun_proxyHTTPVersion(t *testing.T) {
+	t.Run("should disable HTTP/2 on the ServersTransport referenced by the IngressRoute", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.BackendProtocol):  "HTTPS",
+			string(models.ProxyHTTPVersion): "1.1",
+		})
+		withBackend(ctx)
+
+		if err := convert.Run(*ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.ServersTransports) != 1 || !ctx.Result.ServersTransports[0].Spec.DisableHTTP2 {
+			t.Fatalf("expected a ServersTransport disabling HTTP/2, got %+v", ctx.Result.ServersTransports)
+		}
+
+		if len(ctx.Result.IngressRoutes) != 1 {
+			t.Fatalf("expected an IngressRoute, got %d", len(ctx.Result.IngressRoutes))
+		}
+
+		service := ctx.Result.IngressRoutes[0].Spec.Routes[0].Services[0]
+		if service.ServersTransport != "test-transport" {
+			t.Errorf("expected the service to reference test-transport, got %q", service.ServersTransport)
+		}
+	})
+
+	t.Run("should skip unsupported versions", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{string(models.ProxyHTTPVersion): "2.0"})
+		withBackend(ctx)
+
+		if err := convert.Run(*ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.ServersTransports) != 0 || len(ctx.Result.IngressRoutes) != 0 {
+			t.Errorf("expected no ServersTransport and no IngressRoute, got %d and %d",
+				len(ctx.Result.ServersTransports), len(ctx.Result.IngressRoutes))
+		}
+	})
+}
+
+func TestRun_proxySSLSecret(t *testing.T) {
+	t.Run("should present the client certificate of the secret to the backend", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.BackendProtocol): "HTTPS",
+			string(models.ProxySSLSecret):  "default/backend-client",
+		})
+		ctx.Options.Secrets = func(_, _ string) ([]string, error) {
+			return []string{"ca.crt", "tls.crt", "tls.key"}, nil
+		}
+		withBackend(ctx)
+
+		if err := convert.Run(*ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.ServersTransports) != 1 {
+			t.Fatalf("expected a ServersTransport, got %d", len(ctx.Result.ServersTransports))
+		}
+
+		secrets := ctx.Result.ServersTransports[0].Spec.CertificatesSecrets
+		if len(secrets) != 1 || secrets[0] != "backend-client" {
+			t.Errorf("expected certificatesSecrets [backend-client], got %v", secrets)
+		}
+
+		if status := reportStatus(ctx, models.ProxySSLSecret); status != configs.AnnotationConverted {
+			t.Errorf("expected proxy-ssl-secret to be converted, got %q", status)
+		}
+	})
+
+	t.Run("should warn when the secret has no client certificate", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.BackendProtocol): "HTTPS",
+			string(models.ProxySSLSecret):  "other/backend-client",
+		})
+		ctx.Options.Secrets = func(_, _ string) ([]string, error) {
+			return []string{"ca.crt"}, nil
+		}
+		withBackend(ctx)
+
+		if err := convert.Run(*ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		warnings := strings.Join(ctx.Result.Warnings, "\n")
+
+		if !strings.Contains(warnings, "has no tls.crt, tls.key") {
+			t.Errorf("expected a missing key warning, got %v", ctx.Result.Warnings)
+		}
+
+		if !strings.Contains(warnings, `copy the secret "backend-client" into the namespace "default"`) {
+			t.Errorf("expected a namespace warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
+
+func TestRun_proxySSL(t *testing.T) {
+	t.Run("should verify the backend against the CA of the proxy-ssl-secret", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.BackendProtocol):    "HTTPS",
+			string(models.ProxySSLSecret):     "default/backend-client",
+			string(models.ProxySSLVerify):     "on",
+			string(models.ProxySSLName):       "backend.internal",
+			string(models.ProxySSLServerName): "on",
+			string(models.ProxySSLCiphers):    "HIGH:!aNULL",
+		})
+		withBackend(ctx)
+
+		if err := convert.Run(*ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.ServersTransports) != 1 {
+			t.Fatalf("expected a ServersTransport, got %d", len(ctx.Result.ServersTransports))
+		}
+
+		spec := ctx.Result.ServersTransports[0].Spec
+
+		if len(spec.RootCAs) != 1 || spec.RootCAs[0].Secret == nil || *spec.RootCAs[0].Secret != "backend-client" {
+			t.Errorf("expected rootCAs from backend-client, got %+v", spec.RootCAs)
+		}
+
+		if spec.ServerName != "backend.internal" || spec.InsecureSkipVerify {
+			t.Errorf("expected serverName backend.internal with verification, got %+v", spec)
+		}
+
+		if !strings.Contains(strings.Join(ctx.Result.Warnings, "\n"), `proxy-ssl-ciphers "HIGH:!aNULL" cannot be configured`) {
+			t.Errorf("expected a ciphers warning, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should skip verification when proxy-ssl-verify is off", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.BackendProtocol): "HTTPS",
+			string(models.ProxySSLVerify):  "off",
+		})
+		withBackend(ctx)
+
+		if err := convert.Run(*ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.ServersTransports) != 1 || !ctx.Result.ServersTransports[0].Spec.InsecureSkipVerify {
+			t.Errorf("expected insecureSkipVerify, got %+v", ctx.Result.ServersTransports)
+		}
+	})
+}
+
+func TestRun_upstreamKeepalive(t *testing.T) {
+	t.Run("should configure the connection pool of the ServersTransport", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.UpstreamKeepalive):        "64",
+			string(models.UpstreamKeepaliveTimeout): "60",
+			string(models.UpstreamKeepaliveReqs):    "1000",
+		})
+		withBackend(ctx)
+
+		if err := convert.Run(*ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.ServersTransports) != 1 {
+			t.Fatalf("expected a ServersTransport, got %d", len(ctx.Result.ServersTransports))
+		}
+
+		spec := ctx.Result.ServersTransports[0].Spec
+
+		if spec.MaxIdleConnsPerHost != 64 {
+			t.Errorf("expected maxIdleConnsPerHost 64, got %d", spec.MaxIdleConnsPerHost)
+		}
+
+		if spec.ForwardingTimeouts == nil || spec.ForwardingTimeouts.IdleConnTimeout.String() != "60s" {
+			t.Errorf("expected idleConnTimeout 60s, got %+v", spec.ForwardingTimeouts)
+		}
+
+		if !strings.Contains(strings.Join(ctx.Result.Warnings, "\n"), `upstream-keepalive-requests "1000" cannot be configured`) {
+			t.Errorf("expected a keepalive requests warning, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should disable idle connections for 0", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{string(models.UpstreamKeepalive): "0"})
+		withBackend(ctx)
+
+		if err := convert.Run(*ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.ServersTransports) != 1 || ctx.Result.ServersTransports[0].Spec.MaxIdleConnsPerHost != -1 {
+			t.Errorf("expected maxIdleConnsPerHost -1, got %+v", ctx.Result.ServersTransports)
+		}
+	})
+
+	t.Run("should read the NGINX day unit of the keepalive timeout", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{string(models.UpstreamKeepaliveTimeout): "1d"})
+		withBackend(ctx)
+
+		if err := convert.Run(*ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.ServersTransports) != 1 {
+			t.Fatalf("expected a ServersTransport, got %d", len(ctx.Result.ServersTransports))
+		}
+
+		if timeouts := ctx.Result.ServersTransports[0].Spec.ForwardingTimeouts; timeouts == nil || timeouts.IdleConnTimeout.String() != "86400s" {
+			t.Errorf("expected idleConnTimeout 86400s, got %+v", timeouts)
+		}
+	})
+}
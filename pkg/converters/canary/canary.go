@@ -0,0 +1,215 @@
+package canary
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/errors"
+	traefik "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// defaultWeightTotal is the ingress-nginx total the canary-weight is relative to
+// when canary-weight-total is not set.
+const defaultWeightTotal = 100
+
+// HandleCanaryIngress handles the below annotations of a canary Ingress.
+// Annotations:
+//   - "nginx.ingress.kubernetes.io/canary"
+//   - "nginx.ingress.kubernetes.io/canary-weight"
+//   - "nginx.ingress.kubernetes.io/canary-weight-total"
+//   - "nginx.ingress.kubernetes.io/canary-by-header"
+//   - "nginx.ingress.kubernetes.io/canary-by-header-value"
+//   - "nginx.ingress.kubernetes.io/canary-by-header-pattern"
+//   - "nginx.ingress.kubernetes.io/canary-by-cookie"
+//
+// The canary Ingress gets no routing of its own, its backend is weighted into the
+// routers of the primary Ingress by ApplyCanary.
+func HandleCanaryIngress(ctx configs.Context) {
+	ann := string(models.Canary)
+	name := ctx.Namespace + "/" + ctx.IngressName
+
+	primary := ""
+
+	for key, canaries := range ctx.Options.Canaries {
+		for _, canary := range canaries {
+			if canary.Ingress == name {
+				primary = key
+			}
+		}
+	}
+
+	if primary == "" {
+		msg := fmt.Sprintf("canary Ingress %s has no primary Ingress with the same host and path, no routing was generated", name)
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportSkipped(ann, msg)
+
+		return
+	}
+
+	ctx.Result.Notes = append(ctx.Result.Notes, fmt.Sprintf(
+		"canary Ingress %s is routed through the weighted TraefikService of its primary Ingress %s", name, primary,
+	))
+
+	ctx.ReportConverted(ann)
+
+	if _, ok := ctx.Annotations[string(models.CanaryWeightTotal)]; ok {
+		if _, err := weightTotal(ctx.Annotations); err != nil {
+			ctx.Result.Warnings = append(ctx.Result.Warnings, err.Error())
+			ctx.ReportWarning(string(models.CanaryWeightTotal), err.Error())
+		} else {
+			ctx.ReportConverted(string(models.CanaryWeightTotal))
+		}
+	}
+
+	if _, ok := ctx.Annotations[string(models.CanaryWeight)]; ok {
+		if _, err := canaryWeight(ctx.Annotations); err != nil {
+			ctx.Result.Warnings = append(ctx.Result.Warnings, err.Error())
+			ctx.ReportWarning(string(models.CanaryWeight), err.Error())
+		} else {
+			ctx.ReportConverted(string(models.CanaryWeight))
+		}
+	}
+
+	reportCanaryMatchers(ctx)
+}
+
+// ApplyCanary routes the paths of the primary Ingress which have canaries through
+// a weighted round robin TraefikService, splitting the traffic by canary-weight.
+// Header and cookie canaries get additional routes, which the weighted one excludes.
+// The routes must be in the order of the paths they were built from.
+func ApplyCanary(ingressRoute *traefik.IngressRoute, paths []configs.IngressPath, ctx configs.Context) {
+	matched := make([]traefik.Route, 0)
+
+	for index := range ingressRoute.Spec.Routes {
+		route := &ingressRoute.Spec.Routes[index]
+
+		canaries := pairedWith(ctx, paths[index])
+		if len(canaries) == 0 || len(route.Services) != 1 {
+			continue
+		}
+
+		name := fmt.Sprintf("%s-canary", ctx.IngressName)
+		if len(ctx.Result.TraefikServices) > 0 {
+			name = fmt.Sprintf("%s-canary-%d", ctx.IngressName, len(ctx.Result.TraefikServices))
+		}
+
+		primary := route.Services[0]
+		services := make([]traefik.Service, 0, len(canaries)+1)
+
+		// Traefik computes the priority from the rule length, which the matchers extend.
+		priority := route.Priority
+		if priority == 0 {
+			priority = len(route.Match)
+		}
+
+		excluded := make([]string, 0)
+		// ingress-nginx serves a single canary per path, the first one sets the total.
+		remaining, _ := weightTotal(canaries[0].Annotations)
+
+		for _, canary := range canaries {
+			service := primary
+			service.Name = canary.Path.Backend.Name
+			service.Port = backendPort(canary.Path)
+
+			routes, rules := canaryRoutes(*route, priority, excluded, primary, service, canary.Annotations)
+
+			matched = append(matched, routes...)
+			excluded = append(excluded, rules...)
+
+			weight, err := canaryWeight(canary.Annotations)
+			if err != nil {
+				ctx.Result.Warnings = append(ctx.Result.Warnings, err.Error())
+			}
+
+			weight = min(weight, remaining)
+			remaining -= weight
+
+			service.Weight = &weight
+
+			services = append(services, service)
+		}
+
+		primary.Weight = &remaining
+		services = append([]traefik.Service{primary}, services...)
+
+		if len(excluded) > 0 {
+			route.Match = excludeRules(route.Match, excluded)
+			route.Priority = priority
+		}
+
+		ctx.Result.TraefikServices = append(ctx.Result.TraefikServices, &traefik.TraefikService{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: traefik.SchemeGroupVersion.String(),
+				Kind:       "TraefikService",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: ctx.Namespace,
+			},
+			Spec: traefik.TraefikServiceSpec{
+				Weighted: &traefik.WeightedRoundRobin{Services: services},
+			},
+		})
+
+		route.Services = []traefik.Service{{
+			LoadBalancerSpec: traefik.LoadBalancerSpec{
+				Name: name,
+				Kind: "TraefikService",
+			},
+		}}
+	}
+
+	ingressRoute.Spec.Routes = append(ingressRoute.Spec.Routes, matched...)
+}
+
+// canaryWeight returns the canary-weight of the canary Ingress, 0 when it is not set.
+func canaryWeight(annotations map[string]string) (int, error) {
+	val, ok := annotations[string(models.CanaryWeight)]
+	if !ok {
+		return 0, nil
+	}
+
+	total, _ := weightTotal(annotations)
+
+	weight, err := strconv.Atoi(strings.TrimSpace(val))
+	if err != nil || weight < 0 || weight > total {
+		return 0, &errors.ConverterError{Message: fmt.Sprintf(
+			"canary-weight has an invalid value %q (expected 0-%d), the canary receives no weighted traffic", val, total,
+		)}
+	}
+
+	return weight, nil
+}
+
+// weightTotal returns the canary-weight-total of the canary Ingress, the default
+// total of 100 when it is not set or invalid.
+func weightTotal(annotations map[string]string) (int, error) {
+	val, ok := annotations[string(models.CanaryWeightTotal)]
+	if !ok {
+		return defaultWeightTotal, nil
+	}
+
+	total, err := strconv.Atoi(strings.TrimSpace(val))
+	if err != nil || total <= 0 {
+		return defaultWeightTotal, &errors.ConverterError{Message: fmt.Sprintf(
+			"canary-weight-total has an invalid value %q (expected a positive integer), the default total of %d is used",
+			val, defaultWeightTotal,
+		)}
+	}
+
+	return total, nil
+}
+
+func backendPort(path configs.IngressPath) intstr.IntOrString {
+	if path.Backend.Port.Name != "" {
+		return intstr.FromString(path.Backend.Port.Name)
+	}
+
+	return intstr.FromInt32(path.Backend.Port.Number)
+}
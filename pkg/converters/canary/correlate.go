@@ -0,0 +1,84 @@
+package canary
+
+import (
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+	netv1 "k8s.io/api/networking/v1"
+)
+
+// IsCanary reports whether the annotations mark the Ingress as canary.
+func IsCanary(annotations map[string]string) bool {
+	return strings.EqualFold(strings.TrimSpace(annotations[string(models.Canary)]), "true")
+}
+
+// Correlate pairs the paths of every canary Ingress with the primary Ingress serving
+// the same host and path in the same namespace, as ingress-nginx does. Canary paths
+// without a primary are left out.
+func Correlate(ingresses []netv1.Ingress) configs.CanaryPairs {
+	pairs := make(configs.CanaryPairs)
+
+	for canaryIndex := range ingresses {
+		canaryIngress := &ingresses[canaryIndex]
+		if !IsCanary(canaryIngress.Annotations) {
+			continue
+		}
+
+		for _, path := range ingressPaths(canaryIngress) {
+			primary := findPrimary(ingresses, canaryIngress.Namespace, path)
+			if primary == nil {
+				continue
+			}
+
+			key := primary.Namespace + "/" + primary.Name
+
+			pairs[key] = append(pairs[key], configs.Canary{
+				Ingress:     canaryIngress.Namespace + "/" + canaryIngress.Name,
+				Path:        path,
+				Annotations: canaryIngress.Annotations,
+			})
+		}
+	}
+
+	return pairs
+}
+
+// findPrimary returns the non canary Ingress of the namespace serving the host and path.
+func findPrimary(ingresses []netv1.Ingress, namespace string, canaryPath configs.IngressPath) *netv1.Ingress {
+	for index := range ingresses {
+		ingress := &ingresses[index]
+		if ingress.Namespace != namespace || IsCanary(ingress.Annotations) {
+			continue
+		}
+
+		for _, path := range ingressPaths(ingress) {
+			if samePath(path, canaryPath) {
+				return ingress
+			}
+		}
+	}
+
+	return nil
+}
+
+// pairedWith returns the canaries of the primary Ingress paired with the path.
+func pairedWith(ctx configs.Context, path configs.IngressPath) []configs.Canary {
+	canaries := make([]configs.Canary, 0)
+
+	for _, canary := range ctx.Options.CanariesOf(ctx.Namespace, ctx.IngressName) {
+		if samePath(canary.Path, path) {
+			canaries = append(canaries, canary)
+		}
+	}
+
+	return canaries
+}
+
+func samePath(a, b configs.IngressPath) bool {
+	return a.Host == b.Host && a.Path == b.Path
+}
+
+func ingressPaths(ingress *netv1.Ingress) []configs.IngressPath {
+	return (&configs.Context{Ingress: ingress}).Paths()
+}
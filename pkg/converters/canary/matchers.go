@@ -0,0 +1,134 @@
+package canary
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+	traefik "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+)
+
+const (
+	// canaryAlways and canaryNever are the header and cookie values forcing the
+	// request to the canary or the primary backend.
+	canaryAlways = "always"
+	canaryNever  = "never"
+)
+
+// canaryMatch is a matcher sending the requests of a route to a single service.
+type canaryMatch struct {
+	rule    string
+	service traefik.Service
+}
+
+// canaryRoutes returns the routes sending the requests matching canary-by-header and
+// canary-by-cookie of the canary Ingress to the canary or the primary service, along
+// with their matchers. ingress-nginx evaluates the header before the cookie and both
+// before the canary-weight, so every route excludes the matchers evaluated before it
+// and the weighted route excludes all of them. The routes being exclusive, they keep
+// the priority of the weighted route they are derived from and rank against the
+// sibling paths as it does.
+func canaryRoutes(
+	route traefik.Route, priority int, excluded []string, primary, canary traefik.Service, annotations map[string]string,
+) ([]traefik.Route, []string) {
+	matches := make([]canaryMatch, 0)
+
+	if header := strings.TrimSpace(annotations[string(models.CanaryByHeader)]); header != "" {
+		value := strings.TrimSpace(annotations[string(models.CanaryByHeaderValue)])
+		pattern := strings.TrimSpace(annotations[string(models.CanaryByHeaderPattern)])
+
+		switch {
+		case value != "":
+			matches = append(matches, canaryMatch{fmt.Sprintf("Header(`%s`, `%s`)", header, value), canary})
+		case pattern != "":
+			if _, err := regexp.Compile(pattern); err == nil {
+				matches = append(matches, canaryMatch{fmt.Sprintf("HeaderRegexp(`%s`, `%s`)", header, pattern), canary})
+			}
+		default:
+			matches = append(matches,
+				canaryMatch{fmt.Sprintf("Header(`%s`, `%s`)", header, canaryAlways), canary},
+				canaryMatch{fmt.Sprintf("Header(`%s`, `%s`)", header, canaryNever), primary},
+			)
+		}
+	}
+
+	if cookie := strings.TrimSpace(annotations[string(models.CanaryByCookie)]); cookie != "" {
+		matches = append(matches,
+			canaryMatch{cookieMatch(cookie, canaryAlways), canary},
+			canaryMatch{cookieMatch(cookie, canaryNever), primary},
+		)
+	}
+
+	routes := make([]traefik.Route, 0, len(matches))
+	rules := make([]string, 0, len(matches))
+
+	for _, match := range matches {
+		matched := route
+		matched.Match = excludeRules(route.Match, slices.Concat(excluded, rules)) + " && " + match.rule
+		matched.Priority = priority
+		matched.Services = []traefik.Service{match.service}
+
+		routes = append(routes, matched)
+		rules = append(rules, match.rule)
+	}
+
+	return routes, rules
+}
+
+// excludeRules appends the negation of the rules to the route rule.
+func excludeRules(match string, rules []string) string {
+	for _, rule := range rules {
+		match += " && !" + rule
+	}
+
+	return match
+}
+
+// cookieMatch matches the cookie set to the value, Traefik has no cookie matcher.
+func cookieMatch(cookie, value string) string {
+	return fmt.Sprintf("HeaderRegexp(`Cookie`, `(^|;\\s*)%s=%s(;|$)`)", regexp.QuoteMeta(cookie), value)
+}
+
+// reportCanaryMatchers reports the canary-by-header and canary-by-cookie annotations
+// of the canary Ingress, which are routed by the IngressRoute of the primary Ingress.
+func reportCanaryMatchers(ctx configs.Context) {
+	header := strings.TrimSpace(ctx.Annotations[string(models.CanaryByHeader)])
+	value := strings.TrimSpace(ctx.Annotations[string(models.CanaryByHeaderValue)])
+
+	if _, ok := ctx.Annotations[string(models.CanaryByHeader)]; ok {
+		ctx.ReportConverted(string(models.CanaryByHeader))
+	}
+
+	if _, ok := ctx.Annotations[string(models.CanaryByHeaderValue)]; ok {
+		if header == "" {
+			ctx.ReportIgnored(string(models.CanaryByHeaderValue), "canary-by-header-value has no effect without canary-by-header")
+		} else {
+			ctx.ReportConverted(string(models.CanaryByHeaderValue))
+		}
+	}
+
+	if pattern, ok := ctx.Annotations[string(models.CanaryByHeaderPattern)]; ok {
+		_, err := regexp.Compile(strings.TrimSpace(pattern))
+
+		switch {
+		case header == "":
+			ctx.ReportIgnored(string(models.CanaryByHeaderPattern), "canary-by-header-pattern has no effect without canary-by-header")
+		case value != "":
+			ctx.ReportIgnored(string(models.CanaryByHeaderPattern), "canary-by-header-pattern is ignored when canary-by-header-value is set")
+		case err != nil:
+			msg := fmt.Sprintf("canary-by-header-pattern %q is not a valid Go regex for Traefik, no header route was generated", pattern)
+
+			ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+			ctx.ReportWarning(string(models.CanaryByHeaderPattern), msg)
+		default:
+			ctx.ReportConverted(string(models.CanaryByHeaderPattern))
+		}
+	}
+
+	if _, ok := ctx.Annotations[string(models.CanaryByCookie)]; ok {
+		ctx.ReportConverted(string(models.CanaryByCookie))
+	}
+}
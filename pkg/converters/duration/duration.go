@@ -0,0 +1,52 @@
+// Package duration parses the NGINX time values of the annotations and renders them
+// for the Traefik configuration.
+package duration
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/errors"
+)
+
+var durationRe = regexp.MustCompile(`^(\d+)(ms|s|m|h|d)?$`)
+
+// Parse parses an NGINX time value such as "60s", "500ms" or "1m".
+// As in NGINX a plain number is read as seconds.
+func Parse(val string) (time.Duration, error) {
+	match := durationRe.FindStringSubmatch(strings.ToLower(strings.TrimSpace(val)))
+	if match == nil {
+		return 0, &errors.ConverterError{Message: fmt.Sprintf("invalid duration value: %s", val)}
+	}
+
+	n, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil {
+		return 0, &errors.ConverterError{Message: fmt.Sprintf("invalid duration value: %s", val)}
+	}
+
+	const day = 24 * time.Hour
+
+	unit := map[string]time.Duration{
+		"ms": time.Millisecond,
+		"":   time.Second,
+		"s":  time.Second,
+		"m":  time.Minute,
+		"h":  time.Hour,
+		"d":  day,
+	}[match[2]]
+
+	return time.Duration(n) * unit, nil
+}
+
+// Format renders a duration for the Traefik configuration, using whole
+// seconds where possible, e.g. "60s" instead of "1m0s".
+func Format(d time.Duration) string {
+	if d%time.Second != 0 {
+		return strconv.FormatInt(d.Milliseconds(), 10) + "ms"
+	}
+
+	return strconv.FormatInt(int64(d/time.Second), 10) + "s"
+}
@@ -1,14 +1,26 @@
 package ingressroute
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
 	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
 	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // NeedsIngressRoute makes the decision on requirement of ingress routes.
-func NeedsIngressRoute(ann map[string]string) bool {
+// An IngressRoute is needed when the Ingress has at least one path and either
+// the backend scheme cannot be expressed on a plain Ingress, or middlewares were
+// generated which have to be attached to the routers of its paths.
+func NeedsIngressRoute(ctx configs.Context) bool {
+	if len(ctx.Paths()) == 0 {
+		return false
+	}
+
+	ann := ctx.Annotations
+
 	if ann[string(models.GrpcBackend)] == "true" {
 		return true
 	}
@@ -17,10 +29,38 @@ func NeedsIngressRoute(ann map[string]string) bool {
 		return true
 	}
 
-	return false
+	if _, ok := ctx.Result.TLSOptionRefs[ctx.IngressName]; ok {
+		return true
+	}
+
+	if _, ok := ctx.Result.ServersTransportRefs[ctx.IngressName]; ok {
+		return true
+	}
+
+	if _, ok := ctx.Result.LoadBalancers[ctx.IngressName]; ok {
+		return true
+	}
+
+	if _, ok := defaultBackend(ctx); ok {
+		return true
+	}
+
+	if _, ok := ctx.Result.Mirrors[ctx.IngressName]; ok {
+		return true
+	}
+
+	if len(ctx.Options.CanariesOf(ctx.Namespace, ctx.IngressName)) > 0 {
+		return true
+	}
+
+	return len(ctx.Result.Middlewares) > 0
 }
 
-func resolveScheme(annotations map[string]string) (string, error) {
+// resolveScheme returns the scheme of the IngressRoute services. An empty scheme
+// without error means the backend protocol cannot be served by Traefik, which was
+// reported already, and no IngressRoute should be built.
+func resolveScheme(ctx configs.Context) (string, error) {
+	annotations := ctx.Annotations
 	backendProto := strings.ToUpper(annotations[string(models.BackendProtocol)])
 	grpcBackend := annotations[string(models.GrpcBackend)] == "true"
 
@@ -38,6 +78,13 @@ func resolveScheme(annotations map[string]string) (string, error) {
 
 		return "http", nil
 
+	case "AUTO_HTTP":
+		ctx.Result.Notes = append(ctx.Result.Notes,
+			"backend-protocol AUTO_HTTP was mapped to http, Traefik forwards requests over HTTP/1.1 to plain HTTP backends",
+		)
+
+		return "http", nil
+
 	case "HTTPS":
 		return "https", nil
 
@@ -49,6 +96,16 @@ func resolveScheme(annotations map[string]string) (string, error) {
 		// gRPC over TLS
 		return "https", nil
 
+	case "FCGI":
+		msg := "backend-protocol FCGI is not supported by Traefik, which cannot speak FastCGI to backends; " +
+			"put an HTTP frontend such as a FastCGI plugin or an nginx/caddy sidecar in front of the FastCGI server " +
+			"and route to it over HTTP, no IngressRoute was generated"
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportSkipped(string(models.BackendProtocol), msg)
+
+		return "", nil
+
 	default:
 		return "", &errors.ConverterError{Message: "unsupported backend-protocol"}
 	}
@@ -64,3 +121,38 @@ func entryPointsForScheme(scheme string) []string {
 		return []string{"web"}
 	}
 }
+
+// resolveServicePort returns the port of the IngressRoute service for a path,
+// keeping named ports so that Traefik resolves them against the Service. It
+// warns when the port is missing or looks like a plain HTTP port while the
+// backend is reached over TLS.
+func resolveServicePort(ctx configs.Context, path configs.IngressPath, scheme string) intstr.IntOrString {
+	svc := path.Backend
+
+	var port intstr.IntOrString
+
+	switch {
+	case svc.Port.Name != "":
+		port = intstr.FromString(svc.Port.Name)
+	case svc.Port.Number != 0:
+		port = intstr.FromInt32(svc.Port.Number)
+	default:
+		msg := fmt.Sprintf("the port of service '%s' for path '%s' cannot be determined; "+
+			"set the port of the generated IngressRoute service manually", svc.Name, path.Path)
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportWarning(string(models.BackendProtocol), msg)
+
+		return port
+	}
+
+	if scheme == "https" && (port.StrVal == "http" || port.IntVal == 80) {
+		msg := fmt.Sprintf("backend-protocol uses TLS but service '%s' targets port '%s' which looks like "+
+			"a plain HTTP port; make sure it points to the TLS port of the service", svc.Name, port.String())
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportWarning(string(models.BackendProtocol), msg)
+	}
+
+	return port
+}
@@ -0,0 +1,82 @@
+package ingressroute
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+	traefik "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// defaultBackendPriority is the lowest priority a router can have, Traefik treats 0
+// as unset and computes the priority from the length of the rule.
+const defaultBackendPriority = 1
+
+// defaultBackend returns the default-backend annotation when it is routed by catch-all
+// routers, with custom-http-errors it serves the error pages of the Errors middleware.
+func defaultBackend(ctx configs.Context) (string, bool) {
+	backend := strings.TrimSpace(ctx.Annotations[string(models.DefaultBackend)])
+	if backend == "" || strings.TrimSpace(ctx.Annotations[string(models.CustomHTTPErrors)]) != "" {
+		return "", false
+	}
+
+	return backend, true
+}
+
+// defaultBackendRoutes returns a catch-all route per host of the Ingress, sending the
+// requests which match no other router to the default-backend.
+func defaultBackendRoutes(ctx configs.Context) []traefik.Route {
+	backend, ok := defaultBackend(ctx)
+	if !ok {
+		return nil
+	}
+
+	svc, found := ctx.DefaultBackendService(backend)
+
+	port := intstr.FromInt32(svc.Port.Number)
+	if svc.Port.Name != "" {
+		port = intstr.FromString(svc.Port.Name)
+	}
+
+	hosts := make([]string, 0)
+
+	for _, path := range ctx.Paths() {
+		if !slices.Contains(hosts, path.Host) {
+			hosts = append(hosts, path.Host)
+		}
+	}
+
+	routes := make([]traefik.Route, 0, len(hosts))
+
+	for _, host := range hosts {
+		routes = append(routes, traefik.Route{
+			Kind:     "Rule",
+			Match:    combineMatch(buildHostMatch(host), "PathPrefix(`/`)"),
+			Priority: defaultBackendPriority,
+			Services: []traefik.Service{
+				{
+					LoadBalancerSpec: traefik.LoadBalancerSpec{
+						Name: svc.Name,
+						Port: port,
+					},
+				},
+			},
+		})
+	}
+
+	msg := fmt.Sprintf("default-backend %s is routed by lowest priority catch-all routers of the Ingress hosts; "+
+		"NGINX also serves it when the service has no endpoints, which Traefik cannot reproduce", backend)
+
+	if !found {
+		msg += fmt.Sprintf("; no backend of the Ingress routes to %s, port %s was assumed while ingress-nginx "+
+			"serves the first port of the Service", backend, port.String())
+	}
+
+	ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+	ctx.ReportWarning(string(models.DefaultBackend), msg)
+
+	return routes
+}
@@ -6,12 +6,15 @@ import (
 	"strings"
 
 	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/canary"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/loadbalancer"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/mirror"
 	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
 	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/tls"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/transport"
 	traefik "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
 	netv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // BuildIngressRoute handles the below annotations.
@@ -19,81 +22,78 @@ import (
 //   - "nginx.ingress.kubernetes.io/backend-protocol"
 //   - "nginx.ingress.kubernetes.io/grpc-backend"
 //   - "nginx.ingress.kubernetes.io/use-regex"
+//   - "nginx.ingress.kubernetes.io/default-backend"
 func BuildIngressRoute(ctx configs.Context) error {
 	ing := ctx.Ingress
 
 	// 1️⃣ Resolve backend protocol ONCE (Ingress-wide)
-	scheme, err := resolveScheme(ctx.Annotations)
+	scheme, err := resolveScheme(ctx)
 	if err != nil {
 		return err
 	}
 
+	if scheme == "" {
+		return nil
+	}
+
 	useRegex := strings.ToLower(ctx.Annotations[string(models.UseRegex)]) == "true"
 
 	routes := make([]traefik.Route, 0)
+	routePaths := make([]configs.IngressPath, 0)
 	seen := make(map[string]struct{}) // dedup key set
 
-	for _, rule := range ing.Spec.Rules {
-		if rule.HTTP == nil {
-			continue
+	// every router shares the middleware chain generated from the annotations
+	middlewares := middlewareRefs(ctx)
+
+	for _, path := range ctx.Paths() {
+		svc := path.Backend
+
+		pathMatch, ok := buildPathMatch(path, useRegex)
+		if useRegex && !ok {
+			msg := fmt.Sprintf("use-regex is set but path '%s' is not a valid Go regex for Traefik; fell back to PathPrefix", path.Path)
+
+			ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+			ctx.ReportWarning(string(models.UseRegex), msg)
 		}
 
-		hostMatch := buildHostMatch(rule.Host)
-
-		for _, path := range rule.HTTP.Paths {
-			svc := path.Backend.Service
-			if svc == nil {
-				continue
-			}
-
-			pathMatch, ok := buildPathMatch(path, useRegex)
-			if useRegex && !ok {
-				msg := fmt.Sprintf("use-regex is set but path '%s' is not a valid Go regex for Traefik; fell back to PathPrefix", path.Path)
-
-				ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
-				ctx.ReportWarning(string(models.UseRegex), msg)
-			}
-
-			match := combineMatch(hostMatch, pathMatch)
-
-			// Build a stable dedup key
-			key := fmt.Sprintf(
-				"host=%s|path=%s|pathtype=%s|useregex=%t|svc=%s|port=%d|scheme=%s",
-				rule.Host,
-				path.Path,
-				*path.PathType,
-				useRegex,
-				svc.Name,
-				svc.Port.Number,
-				scheme,
-			)
-
-			if _, exists := seen[key]; exists {
-				continue // skip duplicate route
-			}
-
-			seen[key] = struct{}{}
-
-			route := traefik.Route{
-				Kind:  "Rule",
-				Match: match,
-				Services: []traefik.Service{
-					{
-						LoadBalancerSpec: traefik.LoadBalancerSpec{
-							Name: svc.Name,
-							Port: intstr.IntOrString{
-								Type:   intstr.Int,
-								IntVal: svc.Port.Number,
-							},
-							Scheme: scheme,
-						},
+		match := combineMatch(buildHostMatch(path.Host), pathMatch)
+
+		// Build a stable dedup key
+		key := fmt.Sprintf(
+			"host=%s|path=%s|pathtype=%s|useregex=%t|svc=%s|port=%d|portname=%s|scheme=%s",
+			path.Host,
+			path.Path,
+			path.PathType,
+			useRegex,
+			svc.Name,
+			svc.Port.Number,
+			svc.Port.Name,
+			scheme,
+		)
+
+		if _, exists := seen[key]; exists {
+			continue // skip duplicate route
+		}
+
+		seen[key] = struct{}{}
+
+		route := traefik.Route{
+			Kind:  "Rule",
+			Match: match,
+			Services: []traefik.Service{
+				{
+					LoadBalancerSpec: traefik.LoadBalancerSpec{
+						Name:   svc.Name,
+						Port:   resolveServicePort(ctx, path, scheme),
+						Scheme: scheme,
 					},
 				},
-				Middlewares: middlewareRefs(ctx),
-			}
-
-			routes = append(routes, route)
+			},
+			Middlewares: middlewares,
 		}
+
+		routes = append(routes, route)
+		routePaths = append(routePaths, path)
 	}
 
 	if len(routes) == 0 {
@@ -115,10 +115,27 @@ func BuildIngressRoute(ctx configs.Context) error {
 		},
 	}
 
-	// Apply TLS only if scheme requires it (as discussed earlier)
-	tls.ApplyTLSOption(ingressRoute, ctx, scheme)
+	transport.ApplyServersTransport(ingressRoute, ctx)
+
+	loadbalancer.ApplyLoadBalancer(ingressRoute, ctx)
+
+	// the canary services inherit the transport and load balancing of the primary service.
+	canary.ApplyCanary(ingressRoute, routePaths, ctx)
+
+	// the mirror receives a copy of the requests whichever backend serves them.
+	mirror.ApplyMirror(ingressRoute, ctx)
+
+	// the exempted sources are served by the same backends, canaries included.
+	applyRateLimitExemption(ingressRoute, ctx)
+
+	// the catch-all routes serve another backend, none of the above applies to them.
+	ingressRoute.Spec.Routes = append(ingressRoute.Spec.Routes, defaultBackendRoutes(ctx)...)
+
+	// the routes are split per certificate once every route has been added.
+	tlsRoutes := tls.ApplyTLSOption(ingressRoute, ctx)
 
 	ctx.Result.IngressRoutes = append(ctx.Result.IngressRoutes, ingressRoute)
+	ctx.Result.IngressRoutes = append(ctx.Result.IngressRoutes, tlsRoutes...)
 
 	if useRegex {
 		ctx.ReportConverted(string(models.UseRegex))
@@ -136,8 +153,8 @@ func middlewareRefs(ctx configs.Context) []traefik.MiddlewareRef {
 //nolint:varnamelen
 func orderMiddlewares(mws []*traefik.Middleware) []traefik.MiddlewareRef {
 	var (
-		conditional *traefik.Middleware
-		cors        *traefik.Middleware
+		conditional []*traefik.Middleware
+		cors        []*traefik.Middleware
 		rest        []*traefik.Middleware
 	)
 
@@ -146,11 +163,11 @@ func orderMiddlewares(mws []*traefik.Middleware) []traefik.MiddlewareRef {
 
 		switch {
 		case strings.Contains(name, "conditional-return"):
-			conditional = mw
+			conditional = append(conditional, mw)
 
 		case strings.Contains(name, "cors") || strings.Contains(name, "headers"):
 			// your CORS/snippet headers middleware
-			cors = mw
+			cors = append(cors, mw)
 
 		default:
 			rest = append(rest, mw)
@@ -159,16 +176,10 @@ func orderMiddlewares(mws []*traefik.Middleware) []traefik.MiddlewareRef {
 
 	refs := make([]traefik.MiddlewareRef, 0, len(mws))
 
-	if conditional != nil {
-		refs = append(refs, traefik.MiddlewareRef{Name: conditional.GetName()})
-	}
-
-	if cors != nil {
-		refs = append(refs, traefik.MiddlewareRef{Name: cors.GetName()})
-	}
-
-	for _, mw := range rest {
-		refs = append(refs, traefik.MiddlewareRef{Name: mw.GetName()})
+	for _, group := range [][]*traefik.Middleware{conditional, cors, rest} {
+		for _, mw := range group {
+			refs = append(refs, traefik.MiddlewareRef{Name: mw.GetName()})
+		}
 	}
 
 	return refs
@@ -182,7 +193,7 @@ func buildHostMatch(host string) string {
 	return fmt.Sprintf("Host(`%s`)", host)
 }
 
-func buildPathMatch(path netv1.HTTPIngressPath, useRegex bool) (string, bool) {
+func buildPathMatch(path configs.IngressPath, useRegex bool) (string, bool) {
 	pth := path.Path
 	if pth == "" {
 		pth = "/"
@@ -202,7 +213,7 @@ func buildPathMatch(path netv1.HTTPIngressPath, useRegex bool) (string, bool) {
 		return fmt.Sprintf("PathPrefix(`%s`)", pth), false
 	}
 
-	switch *path.PathType {
+	switch path.PathType {
 	case netv1.PathTypeExact:
 		return fmt.Sprintf("Path(`%s`)", pth), true
 	case netv1.PathTypePrefix:
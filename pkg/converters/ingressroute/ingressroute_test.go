@@ -0,0 +1,205 @@
+package ingressroute_test
+
+import (
+	"io"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/ingressroute"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+	traefik "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func newTestContext(paths ...netv1.HTTPIngressPath) configs.Context {
+	ingress := &netv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: netv1.IngressSpec{
+			Rules: []netv1.IngressRule{
+				{
+					Host: "example.com",
+					IngressRuleValue: netv1.IngressRuleValue{
+						HTTP: &netv1.HTTPIngressRuleValue{Paths: paths},
+					},
+				},
+			},
+		},
+	}
+
+	ctx := configs.New(ingress, configs.NewResult(), configs.NewOptions(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx.StartIngressReport(ingress.Namespace, ingress.Name)
+
+	return *ctx
+}
+
+func newPath(path, service string) netv1.HTTPIngressPath {
+	return netv1.HTTPIngressPath{
+		Path: path,
+		Backend: netv1.IngressBackend{
+			Service: &netv1.IngressServiceBackend{
+				Name: service,
+				Port: netv1.ServiceBackendPort{Number: 80},
+			},
+		},
+	}
+}
+
+func TestBuildIngressRoute(t *testing.T) {
+	t.Run("should generate one router per path sharing the middleware chain", func(t *testing.T) {
+		ctx := newTestContext(newPath("/api", "api"), newPath("/web", "web"))
+		ctx.Result.Middlewares = append(ctx.Result.Middlewares, &traefik.Middleware{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cors", Namespace: "default"},
+		})
+
+		if !ingressroute.NeedsIngressRoute(ctx) {
+			t.Fatalf("expected an IngressRoute to be needed when middlewares were generated")
+		}
+
+		if err := ingressroute.BuildIngressRoute(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.IngressRoutes) != 1 {
+			t.Fatalf("expected a single IngressRoute, got %d", len(ctx.Result.IngressRoutes))
+		}
+
+		routes := ctx.Result.IngressRoutes[0].Spec.Routes
+		if len(routes) != 2 {
+			t.Fatalf("expected two routes, got %d", len(routes))
+		}
+
+		expected := []string{
+			"Host(`example.com`) && PathPrefix(`/api`)",
+			"Host(`example.com`) && PathPrefix(`/web`)",
+		}
+
+		for index, route := range routes {
+			if route.Match != expected[index] {
+				t.Errorf("expected match %q, got %q", expected[index], route.Match)
+			}
+		}
+
+		if !reflect.DeepEqual(routes[0].Middlewares, routes[1].Middlewares) ||
+			len(routes[0].Middlewares) != 1 || routes[0].Middlewares[0].Name != "test-cors" {
+			t.Errorf("expected both routes to share the middleware chain, got %v and %v",
+				routes[0].Middlewares, routes[1].Middlewares)
+		}
+	})
+
+	t.Run("should keep every headers middleware in the chain", func(t *testing.T) {
+		ctx := newTestContext(newPath("/", "app"))
+
+		for _, name := range []string{"test-auth-request-headers", "test-cors", "test-rewrite"} {
+			ctx.Result.Middlewares = append(ctx.Result.Middlewares, &traefik.Middleware{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			})
+		}
+
+		if err := ingressroute.BuildIngressRoute(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := []traefik.MiddlewareRef{{Name: "test-auth-request-headers"}, {Name: "test-cors"}, {Name: "test-rewrite"}}
+		if middlewares := ctx.Result.IngressRoutes[0].Spec.Routes[0].Middlewares; !reflect.DeepEqual(expected, middlewares) {
+			t.Errorf("expected middlewares %v, got %v", expected, middlewares)
+		}
+	})
+
+	t.Run("should not need an IngressRoute without middlewares or backend protocol", func(t *testing.T) {
+		ctx := newTestContext(newPath("/", "app"))
+
+		if ingressroute.NeedsIngressRoute(ctx) {
+			t.Errorf("expected no IngressRoute to be needed")
+		}
+	})
+}
+
+func TestBuildIngressRoute_backendPort(t *testing.T) {
+	t.Run("should keep a named TLS port together with the https scheme", func(t *testing.T) {
+		path := newPath("/", "app")
+		path.Backend.Service.Port = netv1.ServiceBackendPort{Name: "https"}
+
+		ctx := newTestContext(path)
+		ctx.Annotations = map[string]string{string(models.BackendProtocol): "HTTPS"}
+
+		if err := ingressroute.BuildIngressRoute(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		service := ctx.Result.IngressRoutes[0].Spec.Routes[0].Services[0]
+		if service.Scheme != "https" || service.Port != intstr.FromString("https") {
+			t.Errorf("expected https scheme on the named https port, got scheme %q port %v", service.Scheme, service.Port.String())
+		}
+
+		if len(ctx.Result.Warnings) != 0 {
+			t.Errorf("expected no warnings, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should warn when an https backend targets a plain HTTP port", func(t *testing.T) {
+		ctx := newTestContext(newPath("/", "app"))
+		ctx.Annotations = map[string]string{string(models.BackendProtocol): "HTTPS"}
+
+		if err := ingressroute.BuildIngressRoute(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		service := ctx.Result.IngressRoutes[0].Spec.Routes[0].Services[0]
+		if service.Port != intstr.FromInt32(80) {
+			t.Errorf("expected port 80, got %v", service.Port.String())
+		}
+
+		found := false
+
+		for _, warning := range ctx.Result.Warnings {
+			if strings.Contains(warning, "looks like a plain HTTP port") {
+				found = true
+			}
+		}
+
+		if !found {
+			t.Errorf("expected plain HTTP port warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
+
+func TestBuildIngressRoute_backendProtocol(t *testing.T) {
+	t.Run("should map AUTO_HTTP to http with a note", func(t *testing.T) {
+		ctx := newTestContext(newPath("/", "app"))
+		ctx.Annotations = map[string]string{string(models.BackendProtocol): "AUTO_HTTP"}
+
+		if err := ingressroute.BuildIngressRoute(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if scheme := ctx.Result.IngressRoutes[0].Spec.Routes[0].Services[0].Scheme; scheme != "http" {
+			t.Errorf("expected http scheme, got %q", scheme)
+		}
+
+		if len(ctx.Result.Notes) != 1 || !strings.Contains(ctx.Result.Notes[0], "AUTO_HTTP") {
+			t.Errorf("expected an AUTO_HTTP note, got %v", ctx.Result.Notes)
+		}
+	})
+
+	t.Run("should warn about FCGI backends without generating an IngressRoute", func(t *testing.T) {
+		ctx := newTestContext(newPath("/", "app"))
+		ctx.Annotations = map[string]string{string(models.BackendProtocol): "FCGI"}
+
+		if err := ingressroute.BuildIngressRoute(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.IngressRoutes) != 0 {
+			t.Errorf("expected no IngressRoute, got %d", len(ctx.Result.IngressRoutes))
+		}
+
+		if len(ctx.Result.Warnings) != 1 || !strings.Contains(ctx.Result.Warnings[0], "FastCGI plugin") {
+			t.Errorf("expected an FCGI warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
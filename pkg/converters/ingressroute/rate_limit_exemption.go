@@ -0,0 +1,56 @@
+package ingressroute
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	traefik "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+)
+
+// applyRateLimitExemption adds a companion route per rate limited route, serving the
+// limit-whitelist sources without the RateLimit middlewares. The route excludes the
+// sources its companion matches, so both keep the priority of the original route and
+// rank against the other routes as it does.
+func applyRateLimitExemption(ingressRoute *traefik.IngressRoute, ctx configs.Context) {
+	exemption, ok := ctx.Result.RateLimitExemptions[ctx.IngressName]
+	if !ok {
+		return
+	}
+
+	matchers := make([]string, 0, len(exemption.SourceRange))
+	for _, source := range exemption.SourceRange {
+		matchers = append(matchers, fmt.Sprintf("ClientIP(`%s`)", source))
+	}
+
+	sources := "(" + strings.Join(matchers, " || ") + ")"
+	exempted := make([]traefik.Route, 0)
+
+	for index := range ingressRoute.Spec.Routes {
+		route := &ingressRoute.Spec.Routes[index]
+
+		middlewares := slices.DeleteFunc(slices.Clone(route.Middlewares), func(ref traefik.MiddlewareRef) bool {
+			return slices.Contains(exemption.Middlewares, ref.Name)
+		})
+
+		if len(middlewares) == len(route.Middlewares) {
+			continue
+		}
+
+		// Traefik computes the priority from the rule length, which the sources extend.
+		if route.Priority == 0 {
+			route.Priority = len(route.Match)
+		}
+
+		companion := *route
+		companion.Match = route.Match + " && " + sources
+		companion.Middlewares = middlewares
+
+		route.Match += " && !" + sources
+
+		exempted = append(exempted, companion)
+	}
+
+	ingressRoute.Spec.Routes = append(ingressRoute.Spec.Routes, exempted...)
+}
@@ -0,0 +1,188 @@
+package loadbalancer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+// defaultSessionCookieName is the name of the ingress-nginx affinity cookie.
+const defaultSessionCookieName = "INGRESSCOOKIE"
+
+// HandleAffinity handles the below annotations.
+// Annotations:
+//   - "nginx.ingress.kubernetes.io/affinity"
+//   - "nginx.ingress.kubernetes.io/affinity-mode"
+//   - "nginx.ingress.kubernetes.io/session-cookie-name"
+//   - "nginx.ingress.kubernetes.io/session-cookie-path"
+//   - "nginx.ingress.kubernetes.io/session-cookie-domain"
+//   - "nginx.ingress.kubernetes.io/session-cookie-expires"
+//   - "nginx.ingress.kubernetes.io/session-cookie-max-age"
+//   - "nginx.ingress.kubernetes.io/session-cookie-secure"
+//   - "nginx.ingress.kubernetes.io/session-cookie-samesite"
+func HandleAffinity(ctx configs.Context) {
+	ann := string(models.Affinity)
+
+	affinity, ok := ctx.Annotations[ann]
+	if !ok {
+		handleAffinityMode(ctx, false)
+
+		return
+	}
+
+	if !hasCookieAffinity(ctx) {
+		msg := "unsupported value for affinity: " + affinity + " (expected cookie)"
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportSkipped(ann, msg)
+
+		handleAffinityMode(ctx, false)
+
+		return
+	}
+
+	// the ingress-nginx affinity cookie is always HttpOnly.
+	cookie := &dynamic.Cookie{Name: defaultSessionCookieName, HTTPOnly: true}
+
+	if name := strings.TrimSpace(ctx.Annotations[string(models.SessionCookieName)]); name != "" {
+		cookie.Name = name
+
+		ctx.ReportConverted(string(models.SessionCookieName))
+	}
+
+	if path := strings.TrimSpace(ctx.Annotations[string(models.SessionCookiePath)]); path != "" {
+		cookie.Path = &path
+
+		ctx.ReportConverted(string(models.SessionCookiePath))
+	}
+
+	if domain := strings.TrimSpace(ctx.Annotations[string(models.SessionCookieDomain)]); domain != "" {
+		cookie.Domain = domain
+
+		ctx.ReportConverted(string(models.SessionCookieDomain))
+	}
+
+	sessionCookieMaxAge(ctx, cookie)
+
+	sessionCookieAttributes(ctx, cookie)
+
+	loadBalancer(ctx).Sticky = &dynamic.Sticky{Cookie: cookie}
+
+	ctx.ReportConverted(ann)
+
+	handleAffinityMode(ctx, true)
+}
+
+// handleAffinityMode maps how NGINX treats sessions when the upstream changes. A
+// Traefik sticky cookie keeps its server for as long as that server exists, which
+// is the persistent mode; balanced sessions are never moved to new servers.
+func handleAffinityMode(ctx configs.Context, sticky bool) {
+	ann := string(models.AffinityMode)
+
+	mode, ok := ctx.Annotations[ann]
+	if !ok {
+		return
+	}
+
+	if !sticky {
+		ctx.ReportIgnored(ann, "affinity-mode only applies to the affinity cookie")
+
+		return
+	}
+
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "persistent":
+		ctx.ReportConverted(ann)
+	case "balanced":
+		msg := "affinity-mode balanced cannot be reproduced, Traefik does not rebalance sticky sessions when " +
+			"servers are added and only moves a session when its server is gone; set session-cookie-max-age " +
+			"to bound how long a client stays pinned, so that load spreads to new servers as cookies expire"
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportWarning(ann, msg)
+	default:
+		msg := "unsupported value for affinity-mode: " + mode + " (expected balanced or persistent)"
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportSkipped(ann, msg)
+	}
+}
+
+// sessionCookieMaxAge maps the lifetime of the affinity cookie. Traefik only sets
+// Max-Age, which browsers prefer over Expires, so session-cookie-expires is used
+// when no session-cookie-max-age is given.
+func sessionCookieMaxAge(ctx configs.Context, cookie *dynamic.Cookie) {
+	for _, annotation := range []models.Annotation{models.SessionCookieMaxAge, models.SessionCookieExpires} {
+		ann := string(annotation)
+
+		val, ok := ctx.Annotations[ann]
+		if !ok {
+			continue
+		}
+
+		seconds, err := strconv.Atoi(strings.TrimSpace(val))
+		if err != nil || seconds < 0 {
+			msg := fmt.Sprintf("%s has an invalid value %q (expected seconds) and was not converted",
+				strings.TrimPrefix(ann, "nginx.ingress.kubernetes.io/"), val)
+
+			ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+			ctx.ReportSkipped(ann, msg)
+
+			continue
+		}
+
+		if cookie.MaxAge == 0 {
+			cookie.MaxAge = seconds
+		}
+
+		ctx.ReportConverted(ann)
+	}
+}
+
+// sessionCookieAttributes maps the Secure and SameSite attributes of the affinity cookie.
+func sessionCookieAttributes(ctx configs.Context, cookie *dynamic.Cookie) {
+	annSecure := string(models.SessionCookieSecure)
+
+	if secure, ok := ctx.Annotations[annSecure]; ok {
+		cookie.Secure = strings.EqualFold(strings.TrimSpace(secure), "true")
+
+		ctx.ReportConverted(annSecure)
+	}
+
+	annSameSite := string(models.SessionCookieSameSite)
+
+	sameSite, ok := ctx.Annotations[annSameSite]
+	if !ok {
+		return
+	}
+
+	switch value := strings.ToLower(strings.TrimSpace(sameSite)); value {
+	case "none", "lax", "strict":
+		cookie.SameSite = value
+
+		if value == "none" && !cookie.Secure {
+			msg := "session-cookie-samesite None requires session-cookie-secure, browsers reject the affinity cookie otherwise"
+
+			ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+			ctx.ReportWarning(annSameSite, msg)
+
+			return
+		}
+
+		ctx.ReportConverted(annSameSite)
+	default:
+		msg := "unsupported value for session-cookie-samesite: " + sameSite + " (expected None, Lax or Strict)"
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportSkipped(annSameSite, msg)
+	}
+}
+
+// hasCookieAffinity reports whether the ingress pins clients with the affinity cookie.
+func hasCookieAffinity(ctx configs.Context) bool {
+	return strings.EqualFold(strings.TrimSpace(ctx.Annotations[string(models.Affinity)]), "cookie")
+}
@@ -0,0 +1,61 @@
+package loadbalancer
+
+import (
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+// HandleLoadBalance handles the below annotations.
+// Annotations:
+//   - "nginx.ingress.kubernetes.io/load-balance"
+//
+// The NGINX algorithms are mapped onto the closest strategy of the Traefik load balancer.
+func HandleLoadBalance(ctx configs.Context) {
+	ann := string(models.LoadBalance)
+
+	algorithm, ok := ctx.Annotations[ann]
+	if !ok {
+		return
+	}
+
+	// like in ingress-nginx the hash of upstream-hash-by replaces the algorithm.
+	if _, hashed := ctx.Annotations[string(models.UpstreamHashBy)]; hashed {
+		ctx.ReportIgnored(ann, "upstream-hash-by takes precedence over load-balance")
+
+		return
+	}
+
+	var msg string
+
+	switch strings.TrimSpace(algorithm) {
+	case "round_robin":
+		// weighted round robin is the default strategy of Traefik.
+		ctx.ReportConverted(ann)
+
+		return
+	case "ewma":
+		loadBalancer(ctx).Strategy = dynamic.BalancerStrategyLeastTime
+
+		msg = "load-balance ewma was converted to the leasttime strategy, Traefik picks the server with the lowest " +
+			"average response time and in-flight requests but does not decay it like the NGINX moving average"
+	case "ip_hash":
+		loadBalancer(ctx).Strategy = dynamic.BalancerStrategyHRW
+
+		msg = "load-balance ip_hash was converted to the hrw strategy, Traefik hashes the full client IP " +
+			"while NGINX only hashes the first three octets of IPv4 addresses"
+	default:
+		msg = "unsupported value for load-balance: " + algorithm + " (expected round_robin, ewma or ip_hash), " +
+			"the services keep the Traefik default weighted round robin"
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportSkipped(ann, msg)
+
+		return
+	}
+
+	ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+	ctx.ReportWarning(ann, msg)
+}
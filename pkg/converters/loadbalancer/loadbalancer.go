@@ -0,0 +1,45 @@
+package loadbalancer
+
+import (
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	traefik "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+)
+
+// loadBalancer returns the load balancing settings of the ingress, creating them on
+// first use so that every upstream annotation configures the same services.
+func loadBalancer(ctx configs.Context) *configs.LoadBalancer {
+	if ctx.Result.LoadBalancers == nil {
+		ctx.Result.LoadBalancers = make(map[string]*configs.LoadBalancer)
+	}
+
+	lb, ok := ctx.Result.LoadBalancers[ctx.IngressName]
+	if !ok {
+		lb = &configs.LoadBalancer{}
+		ctx.Result.LoadBalancers[ctx.IngressName] = lb
+	}
+
+	return lb
+}
+
+// ApplyLoadBalancer configures every service of the IngressRoute with the load
+// balancing settings of the ingress.
+func ApplyLoadBalancer(ingressRoute *traefik.IngressRoute, ctx configs.Context) {
+	lb, ok := ctx.Result.LoadBalancers[ctx.IngressName]
+	if !ok {
+		return
+	}
+
+	for routeIndex := range ingressRoute.Spec.Routes {
+		services := ingressRoute.Spec.Routes[routeIndex].Services
+
+		for serviceIndex := range services {
+			if lb.Sticky != nil {
+				services[serviceIndex].Sticky = lb.Sticky.DeepCopy()
+			}
+
+			if lb.Strategy != "" {
+				services[serviceIndex].Strategy = lb.Strategy
+			}
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package loadbalancer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+var hashByCookieRe = regexp.MustCompile(`^\$cookie_([a-zA-Z0-9_-]+)$`)
+
+// HandleUpstreamHashBy handles the below annotations.
+// Annotations:
+//   - "nginx.ingress.kubernetes.io/upstream-hash-by"
+//   - "nginx.ingress.kubernetes.io/upstream-hash-by-subset"
+//   - "nginx.ingress.kubernetes.io/upstream-hash-by-subset-size"
+//
+// Traefik cannot hash requests onto servers, client IP and cookie keys are pinned
+// with a sticky cookie which keeps a client on the same server as well.
+func HandleUpstreamHashBy(ctx configs.Context) {
+	ann := string(models.UpstreamHashBy)
+
+	key, ok := ctx.Annotations[ann]
+	if !ok || strings.TrimSpace(key) == "" {
+		return
+	}
+
+	key = strings.TrimSpace(key)
+
+	// like in ingress-nginx the cookie affinity replaces the hashing.
+	if hasCookieAffinity(ctx) {
+		ctx.ReportIgnored(ann, "affinity cookie takes precedence over upstream-hash-by")
+
+		return
+	}
+
+	var msg string
+
+	switch match := hashByCookieRe.FindStringSubmatch(key); {
+	case key == "$remote_addr" || key == "$binary_remote_addr":
+		msg = fmt.Sprintf("upstream-hash-by %s was converted to the sticky cookie %s, clients are pinned per browser "+
+			"instead of per IP; the hrw strategy balances by client IP when cookies are not an option",
+			key, stickyCookieName(ctx))
+	case match != nil:
+		msg = fmt.Sprintf("upstream-hash-by %s was converted to the sticky cookie %s, Traefik pins clients with "+
+			"its own cookie instead of hashing the value of the cookie %s", key, stickyCookieName(ctx), match[1])
+	default:
+		msg = fmt.Sprintf("upstream-hash-by %s has no Traefik equivalent, Traefik has no consistent hashing on "+
+			"request variables; use a consistent hashing load balancer plugin keyed on %s, "+
+			"or the hrw strategy for hashing on the client IP", key, key)
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportSkipped(ann, msg)
+
+		reportHashBySubset(ctx)
+
+		return
+	}
+
+	lb := loadBalancer(ctx)
+	lb.Sticky = &dynamic.Sticky{Cookie: &dynamic.Cookie{
+		Name:     stickyCookieName(ctx),
+		HTTPOnly: true,
+	}}
+
+	ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+	ctx.ReportWarning(ann, msg)
+
+	reportHashBySubset(ctx)
+}
+
+// reportHashBySubset warns about the subset hashing of upstream-hash-by, Traefik
+// always balances over all servers of a service.
+func reportHashBySubset(ctx configs.Context) {
+	for _, annotation := range []models.Annotation{models.UpstreamHashBySubset, models.UpstreamHashBySubsetSize} {
+		ann := string(annotation)
+
+		if _, ok := ctx.Annotations[ann]; !ok {
+			continue
+		}
+
+		msg := strings.TrimPrefix(ann, "nginx.ingress.kubernetes.io/") +
+			" cannot be converted, Traefik does not balance over subsets of the servers"
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportSkipped(ann, msg)
+	}
+}
+
+func stickyCookieName(ctx configs.Context) string {
+	return ctx.IngressName + "-sticky"
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+/* ---------------- APP ROOT ---------------- */
+
+// appRootRegex only matches requests for the root path, so the middleware can be
+// attached to every route of the ingress while redirecting `/` alone.
+const appRootRegex = `^(https?://[^/]+)/(?:\?.*)?$`
+
+// AppRoot handles the below annotations.
+// Annotations:
+//   - "nginx.ingress.kubernetes.io/app-root"
+func AppRoot(ctx configs.Context) {
+	ctx.Log.Debug("running converter AppRoot")
+
+	ann := string(models.AppRoot)
+
+	val, ok := ctx.Annotations[ann]
+	if !ok {
+		return
+	}
+
+	root := strings.TrimSpace(val)
+	if !strings.HasPrefix(root, "/") || strings.ContainsAny(root, " \t") {
+		msg := "app-root must be an absolute path starting with '/': " + val
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportSkipped(ann, msg)
+
+		return
+	}
+
+	if root == "/" {
+		ctx.ReportDefault(ann, "app-root is '/', no redirect is needed")
+
+		return
+	}
+
+	// ingress-nginx answers the root path with `return 302 $scheme://$http_host<app-root>`,
+	// which drops the query string of the request.
+	ctx.Result.Middlewares = append(ctx.Result.Middlewares,
+		newRedirectRegexMiddleware(ctx, "app-root", &dynamic.RedirectRegex{
+			Regex:       appRootRegex,
+			Replacement: "${1}" + root,
+			Permanent:   false,
+		}),
+	)
+
+	ctx.ReportConverted(ann)
+}
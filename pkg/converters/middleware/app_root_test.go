@@ -0,0 +1,74 @@
+package middleware_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestAppRoot(t *testing.T) {
+	t.Run("should redirect only the root path to the app root", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.AppRoot): "/app1",
+		})
+
+		middleware.AppRoot(ctx)
+
+		redirect := findMiddleware(t, ctx, "app-root").Spec.RedirectRegex
+		if redirect == nil {
+			t.Fatalf("expected a RedirectRegex middleware")
+		}
+
+		if redirect.Permanent {
+			t.Errorf("expected a temporary redirect, matching the NGINX 302")
+		}
+
+		re := regexp.MustCompile(redirect.Regex)
+
+		tests := map[string]string{
+			"https://example.com/":      "https://example.com/app1",
+			"http://example.com:8080/":  "http://example.com:8080/app1",
+			"https://example.com/?a=b":  "https://example.com/app1",
+			"https://example.com/app1":  "",
+			"https://example.com/other": "",
+		}
+
+		for url, want := range tests {
+			if !re.MatchString(url) {
+				if want != "" {
+					t.Errorf("expected %q to be redirected", url)
+				}
+
+				continue
+			}
+
+			if want == "" {
+				t.Errorf("expected %q not to be redirected", url)
+
+				continue
+			}
+
+			if got := re.ReplaceAllString(url, redirect.Replacement); got != want {
+				t.Errorf("expected %q to redirect to %q, got %q", url, want, got)
+			}
+		}
+	})
+
+	t.Run("should skip an app-root that is not an absolute path", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.AppRoot): "app1",
+		})
+
+		middleware.AppRoot(ctx)
+
+		if len(ctx.Result.Middlewares) != 0 {
+			t.Errorf("expected no middleware, got %d", len(ctx.Result.Middlewares))
+		}
+
+		if !hasWarning(ctx, "app-root must be an absolute path") {
+			t.Errorf("expected a warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
@@ -1,6 +1,9 @@
 package middleware
 
 import (
+	"fmt"
+	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
@@ -14,7 +17,15 @@ import (
 // HandleAuthURL handles the below annotations.
 // Annotations:
 //   - "nginx.ingress.kubernetes.io/auth-url"
+//   - "nginx.ingress.kubernetes.io/auth-method"
+//   - "nginx.ingress.kubernetes.io/auth-signin"
+//   - "nginx.ingress.kubernetes.io/auth-response-headers"
+//   - "nginx.ingress.kubernetes.io/auth-request-redirect"
+//   - "nginx.ingress.kubernetes.io/auth-proxy-set-headers"
+//   - "nginx.ingress.kubernetes.io/auth-snippet"
 func HandleAuthURL(ctx configs.Context) {
+	ctx.Log.Debug("running converter HandleAuthURL")
+
 	const ann = string(models.AuthURL)
 
 	val, ok := ctx.Annotations[ann]
@@ -25,7 +36,8 @@ func HandleAuthURL(ctx configs.Context) {
 	address := strings.TrimSpace(val)
 
 	// Basic sanity check
-	if !strings.HasPrefix(address, "http://") && !strings.HasPrefix(address, "https://") {
+	parsed, err := url.Parse(address)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
 		msg := "auth-url must be an absolute URL (http:// or https://)"
 		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
 		ctx.ReportSkipped(ann, msg)
@@ -33,6 +45,11 @@ func HandleAuthURL(ctx configs.Context) {
 		return
 	}
 
+	forwardAuth := &traefik.ForwardAuth{
+		Address:            address,
+		TrustForwardHeader: true,
+	}
+
 	ctx.Result.Middlewares = append(ctx.Result.Middlewares, &traefik.Middleware{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: traefik.SchemeGroupVersion.String(),
@@ -43,10 +60,7 @@ func HandleAuthURL(ctx configs.Context) {
 			Namespace: ctx.Namespace,
 		},
 		Spec: traefik.MiddlewareSpec{
-			ForwardAuth: &traefik.ForwardAuth{
-				Address:            address,
-				TrustForwardHeader: true,
-			},
+			ForwardAuth: forwardAuth,
 		},
 	})
 
@@ -55,5 +69,98 @@ func HandleAuthURL(ctx configs.Context) {
 		"auth-url converted to Traefik ForwardAuth middleware; verify headers and auth behavior",
 	)
 
+	if msg := authURLAddressWarning(parsed); msg != "" {
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportWarning(ann, msg)
+	} else {
+		ctx.ReportConverted(ann)
+	}
+
+	authMethod(ctx)
+	authSignin(ctx)
+	authResponseHeaders(ctx, forwardAuth)
+	authRequestHeaders(ctx)
+}
+
+// authURLAddressWarning reports the auth-url addresses which resolve differently
+// or are not interpolated by Traefik.
+func authURLAddressWarning(address *url.URL) string {
+	if strings.Contains(address.String(), "$") {
+		return fmt.Sprintf("auth-url %q contains NGINX variables which Traefik ForwardAuth does not interpolate, "+
+			"the address is used literally", address.String())
+	}
+
+	// Short service names resolve in the namespace of the proxy pod.
+	if host := address.Hostname(); !strings.Contains(host, ".") && host != "localhost" {
+		return fmt.Sprintf("auth-url host %q is resolved relative to the Traefik namespace; "+
+			"use the fully qualified service name <service>.<namespace>.svc.cluster.local", host)
+	}
+
+	return ""
+}
+
+// authResponseHeaders copies the headers of the auth response listed in
+// auth-response-headers to the request forwarded to the backend.
+func authResponseHeaders(ctx configs.Context, forwardAuth *traefik.ForwardAuth) {
+	const ann = string(models.AuthResponseHeaders)
+
+	val, ok := ctx.Annotations[ann]
+	if !ok {
+		return
+	}
+
+	for _, header := range strings.Split(val, ",") {
+		if header = strings.TrimSpace(header); header != "" {
+			forwardAuth.AuthResponseHeaders = append(forwardAuth.AuthResponseHeaders, header)
+		}
+	}
+
+	if len(forwardAuth.AuthResponseHeaders) == 0 {
+		ctx.ReportDefault(ann, "auth-response-headers does not list any header")
+
+		return
+	}
+
 	ctx.ReportConverted(ann)
 }
+
+// authMethod handles auth-method. Traefik sends the auth request with GET, or
+// with the method of the original request when preserveRequestMethod is set.
+func authMethod(ctx configs.Context) {
+	const ann = string(models.AuthMethod)
+
+	val, ok := ctx.Annotations[ann]
+	if !ok {
+		return
+	}
+
+	if method := strings.ToUpper(strings.TrimSpace(val)); method == http.MethodGet {
+		ctx.ReportConverted(ann)
+
+		return
+	}
+
+	msg := fmt.Sprintf("auth-method %q cannot be reproduced, Traefik ForwardAuth sends the auth request with GET; "+
+		"set preserveRequestMethod to forward the method of the original request instead", val)
+
+	ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+	ctx.ReportWarning(ann, msg)
+}
+
+// authSignin handles auth-signin. NGINX redirects unauthenticated requests to the
+// sign-in URL, while Traefik returns the response of the auth service as is.
+func authSignin(ctx configs.Context) {
+	const ann = string(models.AuthSignin)
+
+	val, ok := ctx.Annotations[ann]
+	if !ok || strings.TrimSpace(val) == "" {
+		return
+	}
+
+	msg := fmt.Sprintf("auth-signin %q cannot be reproduced, Traefik ForwardAuth returns the 401 of the auth service "+
+		"instead of redirecting to the sign-in URL; configure the auth service to answer unauthenticated requests "+
+		"with a redirect to the sign-in URL itself", val)
+
+	ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+	ctx.ReportWarning(ann, msg)
+}
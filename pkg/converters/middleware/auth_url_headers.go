@@ -0,0 +1,179 @@
+package middleware
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	traefik "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+/* ---------------- AUTH REQUEST HEADERS ---------------- */
+
+// authRequestRedirectHeader is the header NGINX sets from auth-request-redirect.
+const authRequestRedirectHeader = "X-Auth-Request-Redirect"
+
+// authRequestHeaders handles the headers NGINX sends to the auth endpoint. Traefik
+// ForwardAuth cannot set headers on the auth request, but forwards the headers of
+// the original request, so they are set by a Headers middleware ordered before it.
+func authRequestHeaders(ctx configs.Context) {
+	headers := make(map[string]string)
+
+	if val, ok := ctx.Annotations[string(models.AuthRequestRedirect)]; ok {
+		reportAuthRequestHeaders(ctx, string(models.AuthRequestRedirect), headers, map[string]string{
+			authRequestRedirectHeader: strings.TrimSpace(val),
+		}, nil)
+	}
+
+	if proxyHeaders := authProxySetHeaders(ctx); len(proxyHeaders) > 0 {
+		reportAuthRequestHeaders(ctx, string(models.AuthProxySetHeaders), headers, proxyHeaders, nil)
+	}
+
+	if snippet, ok := ctx.Annotations[string(models.AuthSnippet)]; ok {
+		snippetHeaders, unsupported := parseAuthSnippet(snippet)
+		reportAuthRequestHeaders(ctx, string(models.AuthSnippet), headers, snippetHeaders, unsupported)
+	}
+
+	if len(headers) == 0 {
+		return
+	}
+
+	ctx.Result.Middlewares = append(ctx.Result.Middlewares, &traefik.Middleware{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: traefik.SchemeGroupVersion.String(),
+			Kind:       "Middleware",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mwName(ctx, "auth-request-headers"),
+			Namespace: ctx.Namespace,
+			Annotations: map[string]string{
+				NoteAnnotation: "headers sent to the auth-url endpoint, they also reach the backend",
+			},
+		},
+		Spec: traefik.MiddlewareSpec{
+			Headers: &dynamic.Headers{
+				CustomRequestHeaders: headers,
+			},
+		},
+	})
+}
+
+// reportAuthRequestHeaders adds the headers of the annotation to the headers
+// sent to the auth endpoint, skipping the values depending on NGINX variables
+// which Traefik cannot interpolate. The unsupported messages are reported along.
+func reportAuthRequestHeaders(ctx configs.Context, ann string, headers, values map[string]string, unsupported []string) {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	skipped := make([]string, 0)
+
+	for _, name := range names {
+		if strings.Contains(values[name], "$") {
+			skipped = append(skipped, fmt.Sprintf("%s: %q", name, values[name]))
+
+			continue
+		}
+
+		headers[name] = values[name]
+	}
+
+	if len(skipped) > 0 {
+		unsupported = append(unsupported,
+			"auth request headers use NGINX variables which Traefik cannot set and were not converted: "+strings.Join(skipped, ", "),
+		)
+	}
+
+	if len(unsupported) == 0 {
+		ctx.ReportConverted(ann)
+
+		return
+	}
+
+	ctx.Result.Warnings = append(ctx.Result.Warnings, unsupported...)
+
+	if len(values) == len(skipped) {
+		ctx.ReportSkipped(ann, strings.Join(unsupported, "; "))
+
+		return
+	}
+
+	ctx.ReportWarning(ann, strings.Join(unsupported, "; "))
+}
+
+// parseAuthSnippet returns the proxy_set_header headers of an auth-snippet, which
+// NGINX applies to the auth request, and a message for every other directive.
+func parseAuthSnippet(snippet string) (map[string]string, []string) {
+	headers := make(map[string]string)
+	unsupported := make([]string, 0)
+
+	for _, line := range splitLines(snippet) {
+		if strings.ToLower(directive(line)) != "proxy_set_header" {
+			unsupported = append(unsupported, fmt.Sprintf("auth-snippet directive %q cannot be reproduced with Traefik ForwardAuth", line))
+
+			continue
+		}
+
+		key, val, ok := parseProxySetHeader(line)
+		if !ok {
+			unsupported = append(unsupported, "failed to parse auth-snippet proxy_set_header directive: "+line)
+
+			continue
+		}
+
+		headers[key] = strings.Trim(val, `"'`)
+	}
+
+	return headers, unsupported
+}
+
+// authProxySetHeaders returns the headers of the ConfigMap referenced by
+// auth-proxy-set-headers, as "name" or "namespace/name".
+func authProxySetHeaders(ctx configs.Context) map[string]string {
+	ann := string(models.AuthProxySetHeaders)
+
+	val, ok := ctx.Annotations[ann]
+	if !ok || strings.TrimSpace(val) == "" {
+		return nil
+	}
+
+	namespace, name, found := strings.Cut(strings.TrimSpace(val), "/")
+	if !found {
+		namespace, name = ctx.Namespace, namespace
+	}
+
+	if ctx.Options.ConfigMaps == nil {
+		msg := fmt.Sprintf("auth-proxy-set-headers ConfigMap %s/%s cannot be read, "+
+			"add its headers to the auth-request-headers middleware manually", namespace, name)
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportSkipped(ann, msg)
+
+		return nil
+	}
+
+	data, err := ctx.Options.ConfigMaps(namespace, name)
+	if err != nil {
+		msg := fmt.Sprintf("reading auth-proxy-set-headers ConfigMap %s/%s failed: %s", namespace, name, err.Error())
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportSkipped(ann, msg)
+
+		return nil
+	}
+
+	if len(data) == 0 {
+		ctx.ReportDefault(ann, fmt.Sprintf("auth-proxy-set-headers ConfigMap %s/%s has no headers", namespace, name))
+
+		return nil
+	}
+
+	return data
+}
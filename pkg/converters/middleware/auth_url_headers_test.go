@@ -0,0 +1,106 @@
+package middleware_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestHandleAuthURL_requestHeaders(t *testing.T) {
+	t.Run("should send auth-request-redirect and the auth-proxy-set-headers ConfigMap to the auth endpoint", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.AuthURL):             "https://auth.example.com/check",
+			string(models.AuthRequestRedirect): "https://app.example.com/",
+			string(models.AuthProxySetHeaders): "auth/auth-headers",
+		})
+
+		ctx.Options.ConfigMaps = func(namespace, name string) (map[string]string, error) {
+			if namespace != "auth" || name != "auth-headers" {
+				t.Fatalf("unexpected ConfigMap %s/%s", namespace, name)
+			}
+
+			return map[string]string{
+				"X-Tenant":    "team-a",
+				"X-Client-IP": "$remote_addr",
+			}, nil
+		}
+
+		middleware.HandleAuthURL(ctx)
+
+		headers := findMiddleware(t, ctx, "auth-request-headers").Spec.Headers.CustomRequestHeaders
+
+		if headers["X-Auth-Request-Redirect"] != "https://app.example.com/" || headers["X-Tenant"] != "team-a" {
+			t.Errorf("unexpected auth request headers %v", headers)
+		}
+
+		if _, ok := headers["X-Client-IP"]; ok {
+			t.Errorf("expected headers with NGINX variables to be skipped")
+		}
+
+		if !hasWarning(ctx, `X-Client-IP: "$remote_addr"`) {
+			t.Errorf("expected a variable warning, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should read the ConfigMap from the ingress namespace by default", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.AuthURL):             "https://auth.example.com/check",
+			string(models.AuthProxySetHeaders): "auth-headers",
+		})
+
+		ctx.Options.ConfigMaps = func(namespace, _ string) (map[string]string, error) {
+			if namespace != "default" {
+				t.Errorf("expected the ingress namespace, got %s", namespace)
+			}
+
+			return nil, errors.New("not found")
+		}
+
+		middleware.HandleAuthURL(ctx)
+
+		if !hasWarning(ctx, "reading auth-proxy-set-headers ConfigMap default/auth-headers failed: not found") {
+			t.Errorf("expected a lookup warning, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should warn when ConfigMaps cannot be read", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.AuthURL):             "https://auth.example.com/check",
+			string(models.AuthProxySetHeaders): "auth-headers",
+		})
+
+		middleware.HandleAuthURL(ctx)
+
+		if !hasWarning(ctx, "auth-proxy-set-headers ConfigMap default/auth-headers cannot be read") {
+			t.Errorf("expected a warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
+
+func TestHandleAuthURL_authSnippet(t *testing.T) {
+	t.Run("should send the auth-snippet headers and warn about other directives", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.AuthURL): "https://auth.example.com/check",
+			string(models.AuthSnippet): `proxy_set_header X-Api-Key "secret";
+proxy_set_header X-Original-Host $host;
+proxy_pass_request_body off;`,
+		})
+
+		middleware.HandleAuthURL(ctx)
+
+		headers := findMiddleware(t, ctx, "auth-request-headers").Spec.Headers.CustomRequestHeaders
+		if len(headers) != 1 || headers["X-Api-Key"] != "secret" {
+			t.Errorf("unexpected auth request headers %v", headers)
+		}
+
+		if !hasWarning(ctx, `auth-snippet directive "proxy_pass_request_body off`) {
+			t.Errorf("expected an unsupported directive warning, got %v", ctx.Result.Warnings)
+		}
+
+		if !hasWarning(ctx, `X-Original-Host: "$host"`) {
+			t.Errorf("expected a variable warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
@@ -0,0 +1,101 @@
+package middleware_test
+
+import (
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestHandleAuthURL(t *testing.T) {
+	t.Run("should convert auth-url into a ForwardAuth middleware", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.AuthURL):    "http://oauth2-proxy.auth.svc.cluster.local/oauth2/auth",
+			string(models.AuthMethod): "GET",
+		})
+
+		middleware.HandleAuthURL(ctx)
+
+		forwardAuth := findMiddleware(t, ctx, "auth-url").Spec.ForwardAuth
+		if forwardAuth == nil || forwardAuth.Address != "http://oauth2-proxy.auth.svc.cluster.local/oauth2/auth" {
+			t.Fatalf("unexpected ForwardAuth %+v", forwardAuth)
+		}
+
+		if len(ctx.Result.Warnings) != 1 {
+			t.Errorf("expected only the generic ForwardAuth warning, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantWarning string
+	}{
+		{
+			name:        "should warn about short service names",
+			annotations: map[string]string{string(models.AuthURL): "http://oauth2-proxy/oauth2/auth"},
+			wantWarning: `auth-url host "oauth2-proxy" is resolved relative to the Traefik namespace`,
+		},
+		{
+			name:        "should warn about NGINX variables",
+			annotations: map[string]string{string(models.AuthURL): "https://auth.example.com/check?rd=$escaped_request_uri"},
+			wantWarning: "contains NGINX variables",
+		},
+		{
+			name: "should warn that auth-method cannot be forced",
+			annotations: map[string]string{
+				string(models.AuthURL):    "https://auth.example.com/check",
+				string(models.AuthMethod): "POST",
+			},
+			wantWarning: `auth-method "POST" cannot be reproduced`,
+		},
+		{
+			name: "should warn that auth-signin redirects cannot be reproduced",
+			annotations: map[string]string{
+				string(models.AuthURL):    "https://auth.example.com/check",
+				string(models.AuthSignin): "https://auth.example.com/start?rd=$escaped_request_uri",
+			},
+			wantWarning: "instead of redirecting to the sign-in URL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := newTestContext(tt.annotations)
+
+			middleware.HandleAuthURL(ctx)
+
+			findMiddleware(t, ctx, "auth-url")
+
+			if !hasWarning(ctx, tt.wantWarning) {
+				t.Errorf("expected warning %q, got %v", tt.wantWarning, ctx.Result.Warnings)
+			}
+		})
+	}
+
+	t.Run("should propagate auth-response-headers", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.AuthURL):             "https://auth.example.com/check",
+			string(models.AuthResponseHeaders): "X-Auth-Request-User, X-Auth-Request-Email,",
+		})
+
+		middleware.HandleAuthURL(ctx)
+
+		headers := findMiddleware(t, ctx, "auth-url").Spec.ForwardAuth.AuthResponseHeaders
+		if len(headers) != 2 || headers[0] != "X-Auth-Request-User" || headers[1] != "X-Auth-Request-Email" {
+			t.Errorf("unexpected authResponseHeaders %v", headers)
+		}
+	})
+
+	t.Run("should skip relative auth urls", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.AuthURL): "/oauth2/auth",
+		})
+
+		middleware.HandleAuthURL(ctx)
+
+		if len(ctx.Result.Middlewares) != 0 {
+			t.Errorf("expected no middleware, got %d", len(ctx.Result.Middlewares))
+		}
+	})
+}
@@ -1,18 +1,34 @@
 package middleware
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
 	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
 	traefik "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 /* ---------------- BASIC AUTH ---------------- */
 
+const (
+	// authSecretTypeFile is the NGINX secret layout holding a htpasswd file in the `auth` key.
+	authSecretTypeFile = "auth-file"
+	// authSecretTypeMap is the NGINX secret layout holding one key per user with the hashed password as value.
+	authSecretTypeMap = "auth-map"
+	// authMapJobImage is the kubectl image of the Job transforming an auth-map secret.
+	authMapJobImage = "bitnami/kubectl:1.31.4"
+)
+
 // BasicAuth handles the below annotations.
 // Annotations:
 //   - "nginx.ingress.kubernetes.io/auth-type"
 //   - "nginx.ingress.kubernetes.io/auth-secret"
+//   - "nginx.ingress.kubernetes.io/auth-secret-type"
 //   - "nginx.ingress.kubernetes.io/auth-realm"
 func BasicAuth(ctx configs.Context) {
 	ctx.Log.Debug("running converter BasicAuth")
@@ -22,12 +38,63 @@ func BasicAuth(ctx configs.Context) {
 		return
 	}
 
+	if val == "digest" {
+		// converted by DigestAuth.
+		return
+	}
+
 	if val != "basic" {
 		ctx.ReportSkipped(string(models.AuthType), "not of type basic")
 
 		return
 	}
 
+	secret, secretWarning, ok := authSecretName(ctx)
+	if !ok {
+		return
+	}
+
+	annSecretType := string(models.AuthSecretType)
+
+	secretType := authSecretTypeFile
+	if v, ok := ctx.Annotations[annSecretType]; ok {
+		secretType = strings.TrimSpace(v)
+	}
+
+	switch secretType {
+	case authSecretTypeFile:
+		ctx.Result.Notes = append(ctx.Result.Notes, fmt.Sprintf(
+			"basic auth secret %q is read by Traefik as a htpasswd file, it must only contain the 'auth' key", secret,
+		))
+
+		ctx.ReportConverted(annSecretType)
+
+	case authSecretTypeMap:
+		usersSecret := secret + "-users"
+		sourceNamespace := authSecretNamespace(ctx)
+		command := authMapTransformCommand(sourceNamespace, secret, ctx.Namespace, usersSecret)
+
+		ctx.Result.Jobs = append(ctx.Result.Jobs, authMapJob(ctx, usersSecret, command))
+		authMapRBAC(ctx, usersSecret, sourceNamespace, secret)
+
+		msg := fmt.Sprintf("basic auth secret %q uses the auth-map layout which Traefik cannot read; "+
+			"the middleware references the secret %q created by the Job %q, which runs with the ServiceAccount %q "+
+			"allowed to get the secret in namespace %q and to create it in namespace %q; or create it with: %s",
+			secret, usersSecret, usersSecret, usersSecret, sourceNamespace, ctx.Namespace, command)
+
+		// the Job reads the auth-map secret from its own namespace.
+		secret, secretWarning = usersSecret, ""
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportWarning(annSecretType, msg)
+
+	default:
+		msg := fmt.Sprintf("auth-secret-type %q is not supported, the secret is assumed to hold a htpasswd file", secretType)
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportWarning(annSecretType, msg)
+	}
+
 	ctx.Result.Middlewares = append(ctx.Result.Middlewares, &traefik.Middleware{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: traefik.SchemeGroupVersion.String(),
@@ -39,13 +106,183 @@ func BasicAuth(ctx configs.Context) {
 		},
 		Spec: traefik.MiddlewareSpec{
 			BasicAuth: &traefik.BasicAuth{
-				Secret: ctx.Annotations[string(models.AuthSecret)],
+				Secret: secret,
 				Realm:  ctx.Annotations[string(models.AuthRealm)],
 			},
 		},
 	})
 
-	ctx.ReportConverted(string(models.AuthSecret))
+	ctx.ReportConverted(string(models.AuthType))
+
+	if secretWarning != "" {
+		ctx.Result.Warnings = append(ctx.Result.Warnings, secretWarning)
+		ctx.ReportWarning(string(models.AuthSecret), secretWarning)
+	} else {
+		ctx.ReportConverted(string(models.AuthSecret))
+	}
+
+	if _, ok := ctx.Annotations[string(models.AuthRealm)]; ok {
+		ctx.ReportConverted(string(models.AuthRealm))
+	}
+}
+
+// authSecretName returns the secret name of the auth-secret annotation. NGINX
+// accepts a "namespace/name" reference while Traefik only reads secrets of the
+// middleware namespace, references to other namespaces return a warning.
+func authSecretName(ctx configs.Context) (string, string, bool) {
+	val := strings.TrimSpace(ctx.Annotations[string(models.AuthSecret)])
+	if val == "" {
+		msg := "auth-type requires auth-secret, the authentication was not converted"
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportSkipped(string(models.AuthType), msg)
+
+		return "", "", false
+	}
+
+	namespace, name, found := strings.Cut(val, "/")
+	if !found {
+		return val, "", true
+	}
+
+	if namespace != ctx.Namespace {
+		return name, fmt.Sprintf("auth-secret %q is in namespace %q, Traefik only reads secrets from the middleware namespace %q; "+
+			"copy the secret %q into it", val, namespace, ctx.Namespace, name), true
+	}
+
+	return name, "", true
+}
+
+// authSecretNamespace returns the namespace of the auth-secret annotation, which
+// defaults to the namespace of the Ingress.
+func authSecretNamespace(ctx configs.Context) string {
+	val := strings.TrimSpace(ctx.Annotations[string(models.AuthSecret)])
 
-	ctx.ReportConverted(string(models.AuthRealm))
+	if namespace, _, found := strings.Cut(val, "/"); found {
+		return namespace
+	}
+
+	return ctx.Namespace
+}
+
+// authMapTransformCommand returns the command creating a Traefik `users` secret
+// from an NGINX auth-map secret, which holds one key per user with the hashed
+// password as value. The secret data is not available to the converter.
+func authMapTransformCommand(fromNamespace, from, namespace, to string) string {
+	return fmt.Sprintf(
+		"kubectl -n %[1]s get secret %[2]s -o go-template='{{range $user, $hash := .data}}{{$user}}:{{$hash | base64decode}}{{\"\\n\"}}{{end}}' | "+
+			"kubectl -n %[3]s create secret generic %[4]s --from-file=users=/dev/stdin --dry-run=client -o yaml | kubectl apply -f -",
+		fromNamespace, from, namespace, to,
+	)
+}
+
+// authMapJob returns the Job running the transformation command in the cluster, as
+// the converter has no access to the data of the auth-map secret.
+func authMapJob(ctx configs.Context, name, command string) *batchv1.Job {
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: batchv1.SchemeGroupVersion.String(),
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ctx.Namespace,
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					ServiceAccountName: name,
+					RestartPolicy:      corev1.RestartPolicyOnFailure,
+					Containers: []corev1.Container{{
+						Name:    "users",
+						Image:   authMapJobImage,
+						Command: []string{"/bin/sh", "-c", command},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// authMapRBAC adds the ServiceAccount of the auth-map Job with the Roles and RoleBindings
+// allowing it to read the auth-map secret and to create or update the users secret.
+// The Job runs in the Ingress namespace, the auth-map secret may live in another one.
+func authMapRBAC(ctx configs.Context, name, sourceNamespace, source string) {
+	ctx.Result.ServiceAccounts = append(ctx.Result.ServiceAccounts, &corev1.ServiceAccount{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "ServiceAccount",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ctx.Namespace,
+		},
+	})
+
+	readRule := rbacv1.PolicyRule{
+		APIGroups:     []string{""},
+		Resources:     []string{"secrets"},
+		ResourceNames: []string{source},
+		Verbs:         []string{"get"},
+	}
+
+	// create cannot be restricted by resource name, kubectl apply reads and patches the existing secret.
+	writeRules := []rbacv1.PolicyRule{
+		{
+			APIGroups:     []string{""},
+			Resources:     []string{"secrets"},
+			ResourceNames: []string{name},
+			Verbs:         []string{"get", "patch"},
+		},
+		{
+			APIGroups: []string{""},
+			Resources: []string{"secrets"},
+			Verbs:     []string{"create"},
+		},
+	}
+
+	if sourceNamespace == ctx.Namespace {
+		authMapRole(ctx, name, ctx.Namespace, append(writeRules, readRule))
+
+		return
+	}
+
+	authMapRole(ctx, name, sourceNamespace, []rbacv1.PolicyRule{readRule})
+	authMapRole(ctx, name, ctx.Namespace, writeRules)
+}
+
+// authMapRole adds a Role with the given rules and binds it to the ServiceAccount of the auth-map Job.
+func authMapRole(ctx configs.Context, name, namespace string, rules []rbacv1.PolicyRule) {
+	ctx.Result.Roles = append(ctx.Result.Roles, &rbacv1.Role{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: rbacv1.SchemeGroupVersion.String(),
+			Kind:       "Role",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Rules: rules,
+	})
+
+	ctx.Result.RoleBindings = append(ctx.Result.RoleBindings, &rbacv1.RoleBinding{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: rbacv1.SchemeGroupVersion.String(),
+			Kind:       "RoleBinding",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      name,
+			Namespace: ctx.Namespace,
+		}},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     name,
+		},
+	})
 }
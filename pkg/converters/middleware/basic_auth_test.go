@@ -0,0 +1,158 @@
+package middleware_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestBasicAuth(t *testing.T) {
+	t.Run("should reference the auth-file secret and realm", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.AuthType):   "basic",
+			string(models.AuthSecret): "basic-auth",
+			string(models.AuthRealm):  "Authentication Required",
+		})
+
+		middleware.BasicAuth(ctx)
+
+		basicAuth := findMiddleware(t, ctx, "basicauth").Spec.BasicAuth
+		if basicAuth == nil {
+			t.Fatalf("expected a BasicAuth middleware")
+		}
+
+		if basicAuth.Secret != "basic-auth" || basicAuth.Realm != "Authentication Required" {
+			t.Errorf("unexpected basic auth %+v", basicAuth)
+		}
+
+		if len(ctx.Result.Warnings) != 0 {
+			t.Errorf("expected no warnings, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should reference a transformed secret for the auth-map layout", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.AuthType):       "basic",
+			string(models.AuthSecret):     "basic-auth",
+			string(models.AuthSecretType): "auth-map",
+		})
+
+		middleware.BasicAuth(ctx)
+
+		if secret := findMiddleware(t, ctx, "basicauth").Spec.BasicAuth.Secret; secret != "basic-auth-users" {
+			t.Errorf("expected the transformed secret basic-auth-users, got %q", secret)
+		}
+
+		if !hasWarning(ctx, "uses the auth-map layout which Traefik cannot read") {
+			t.Errorf("expected an auth-map warning, got %v", ctx.Result.Warnings)
+		}
+
+		if !hasWarning(ctx, "kubectl -n default create secret generic basic-auth-users --from-file=users=/dev/stdin") {
+			t.Errorf("expected the transformation command, got %v", ctx.Result.Warnings)
+		}
+
+		if len(ctx.Result.Jobs) != 1 || ctx.Result.Jobs[0].GetName() != "basic-auth-users" {
+			t.Fatalf("expected a Job creating the transformed secret, got %v", ctx.Result.Jobs)
+		}
+
+		podSpec := ctx.Result.Jobs[0].Spec.Template.Spec
+		if podSpec.ServiceAccountName != "basic-auth-users" {
+			t.Errorf("expected the Job to run as basic-auth-users, got %q", podSpec.ServiceAccountName)
+		}
+
+		if image := podSpec.Containers[0].Image; strings.HasSuffix(image, ":latest") || !strings.Contains(image, ":") {
+			t.Errorf("expected a pinned image tag, got %q", image)
+		}
+
+		if len(ctx.Result.ServiceAccounts) != 1 || ctx.Result.ServiceAccounts[0].GetNamespace() != "default" {
+			t.Errorf("expected a ServiceAccount in the Ingress namespace, got %v", ctx.Result.ServiceAccounts)
+		}
+
+		if len(ctx.Result.Roles) != 1 || len(ctx.Result.Roles[0].Rules) != 3 {
+			t.Fatalf("expected a single Role reading and writing the secrets, got %v", ctx.Result.Roles)
+		}
+
+		if len(ctx.Result.RoleBindings) != 1 || ctx.Result.RoleBindings[0].Subjects[0].Name != "basic-auth-users" {
+			t.Errorf("expected a RoleBinding for the ServiceAccount, got %v", ctx.Result.RoleBindings)
+		}
+	})
+
+	t.Run("should read the auth-map secret from its own namespace", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.AuthType):       "basic",
+			string(models.AuthSecret):     "auth/basic-auth",
+			string(models.AuthSecretType): "auth-map",
+		})
+
+		middleware.BasicAuth(ctx)
+
+		if len(ctx.Result.Jobs) != 1 {
+			t.Fatalf("expected a Job creating the transformed secret, got %d", len(ctx.Result.Jobs))
+		}
+
+		job := ctx.Result.Jobs[0]
+		if job.GetNamespace() != "default" {
+			t.Errorf("expected the Job in the Ingress namespace, got %q", job.GetNamespace())
+		}
+
+		command := strings.Join(job.Spec.Template.Spec.Containers[0].Command, " ")
+		if !strings.Contains(command, "kubectl -n auth get secret basic-auth") ||
+			!strings.Contains(command, "kubectl -n default create secret generic basic-auth-users") {
+			t.Errorf("expected the Job to copy auth/basic-auth into default/basic-auth-users, got %q", command)
+		}
+
+		if len(ctx.Result.Roles) != 2 || len(ctx.Result.RoleBindings) != 2 {
+			t.Fatalf("expected a Role and RoleBinding per namespace, got %d and %d", len(ctx.Result.Roles), len(ctx.Result.RoleBindings))
+		}
+
+		source, target := ctx.Result.Roles[0], ctx.Result.Roles[1]
+		if source.GetNamespace() != "auth" || source.Rules[0].ResourceNames[0] != "basic-auth" || source.Rules[0].Verbs[0] != "get" {
+			t.Errorf("expected a Role reading auth/basic-auth, got %+v", source)
+		}
+
+		if target.GetNamespace() != "default" || target.Rules[0].ResourceNames[0] != "basic-auth-users" {
+			t.Errorf("expected a Role writing default/basic-auth-users, got %+v", target)
+		}
+
+		for _, binding := range ctx.Result.RoleBindings {
+			if subject := binding.Subjects[0]; subject.Name != "basic-auth-users" || subject.Namespace != "default" {
+				t.Errorf("expected %s to bind the Job ServiceAccount, got %+v", binding.GetNamespace(), subject)
+			}
+		}
+
+		if hasWarning(ctx, "copy the secret") {
+			t.Errorf("expected no copy warning, the Job reads the secret from its namespace, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should strip the namespace of the secret and warn about other namespaces", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.AuthType):   "basic",
+			string(models.AuthSecret): "auth/basic-auth",
+		})
+
+		middleware.BasicAuth(ctx)
+
+		if secret := findMiddleware(t, ctx, "basicauth").Spec.BasicAuth.Secret; secret != "basic-auth" {
+			t.Errorf("expected secret basic-auth, got %q", secret)
+		}
+
+		if !hasWarning(ctx, `auth-secret "auth/basic-auth" is in namespace "auth"`) {
+			t.Errorf("expected a namespace warning, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should skip basic auth without a secret", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.AuthType): "basic",
+		})
+
+		middleware.BasicAuth(ctx)
+
+		if len(ctx.Result.Middlewares) != 0 {
+			t.Errorf("expected no middleware, got %d", len(ctx.Result.Middlewares))
+		}
+	})
+}
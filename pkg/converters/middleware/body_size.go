@@ -16,21 +16,36 @@ import (
 // BodySize handles the below annotations.
 // Annotations:
 //   - "nginx.ingress.kubernetes.io/proxy-body-size"
+//   - "nginx.ingress.kubernetes.io/client-body-buffer-size"
+//
+// Both annotations are merged into a single Buffering middleware.
 func BodySize(ctx configs.Context) error {
 	ctx.Log.Debug("running converter BodySize")
 
 	ann := string(models.ProxyBodySize)
 
-	val, ok := ctx.Annotations[ann]
-	if !ok {
-		return nil
-	}
+	buffering := &dynamic.Buffering{}
 
-	intValue, err := parseSizeBytes(val)
-	if err != nil {
-		return &errors.ConverterError{
-			Message: fmt.Sprintf("invalid proxy-body-size %q: %s", val, err.Error()),
+	if val, ok := ctx.Annotations[ann]; ok {
+		intValue, err := parseSizeBytes(val)
+		if err != nil {
+			return &errors.ConverterError{
+				Message: fmt.Sprintf("invalid proxy-body-size %q: %s", val, err.Error()),
+			}
 		}
+
+		// NGINX disables the body size check with 0, which is Traefik's default.
+		if intValue != 0 {
+			buffering.MaxRequestBodyBytes = intValue
+		}
+
+		ctx.ReportConverted(ann)
+	}
+
+	clientBodyBufferSize(ctx, buffering)
+
+	if buffering.MaxRequestBodyBytes == 0 && buffering.MemRequestBodyBytes == 0 {
+		return nil
 	}
 
 	ctx.Result.Middlewares = append(ctx.Result.Middlewares, &traefik.Middleware{
@@ -43,13 +58,43 @@ func BodySize(ctx configs.Context) error {
 			Namespace: ctx.Namespace,
 		},
 		Spec: traefik.MiddlewareSpec{
-			Buffering: &dynamic.Buffering{
-				MaxRequestBodyBytes: intValue,
-			},
+			Buffering: buffering,
 		},
 	})
 
-	ctx.ReportConverted(ann)
-
 	return nil
 }
+
+// clientBodyBufferSize sets the in-memory part of the buffered request body,
+// request bodies larger than it are written to disk as in NGINX.
+func clientBodyBufferSize(ctx configs.Context, buffering *dynamic.Buffering) {
+	ann := string(models.ClientBodyBufferSize)
+
+	val, ok := ctx.Annotations[ann]
+	if !ok {
+		return
+	}
+
+	size, err := parseSizeBytes(val)
+	if err != nil || size == 0 {
+		msg := fmt.Sprintf("client-body-buffer-size %q is not a valid size, it was not converted", val)
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportSkipped(ann, msg)
+
+		return
+	}
+
+	if buffering.MaxRequestBodyBytes > 0 && size > buffering.MaxRequestBodyBytes {
+		msg := fmt.Sprintf("client-body-buffer-size %q exceeds proxy-body-size, it was capped to the maximum body size", val)
+
+		size = buffering.MaxRequestBodyBytes
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportWarning(ann, msg)
+	} else {
+		ctx.ReportConverted(ann)
+	}
+
+	buffering.MemRequestBodyBytes = size
+}
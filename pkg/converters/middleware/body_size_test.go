@@ -0,0 +1,120 @@
+package middleware_test
+
+import (
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestBodySize(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  int64
+	}{
+		{name: "should convert a plain byte value", value: "1024", want: 1024},
+		{name: "should convert a kilobyte value", value: "8k", want: 8 * 1024},
+		{name: "should convert an uppercase megabyte value", value: "8M", want: 8 * 1024 * 1024},
+		{name: "should convert a gigabyte value", value: "1g", want: 1024 * 1024 * 1024},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := newTestContext(map[string]string{
+				string(models.ProxyBodySize): tt.value,
+			})
+
+			if err := middleware.BodySize(ctx); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			buffering := findMiddleware(t, ctx, "bodysize").Spec.Buffering
+			if buffering == nil || buffering.MaxRequestBodyBytes != tt.want {
+				t.Errorf("expected maxRequestBodyBytes %d, got %+v", tt.want, buffering)
+			}
+		})
+	}
+
+	t.Run("should not generate a middleware for an unlimited body size", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ProxyBodySize): "0",
+		})
+
+		if err := middleware.BodySize(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.Middlewares) != 0 {
+			t.Errorf("expected no middleware, got %d", len(ctx.Result.Middlewares))
+		}
+	})
+
+	t.Run("should fail on an invalid size", func(t *testing.T) {
+		for _, value := range []string{"8x", "-1m"} {
+			ctx := newTestContext(map[string]string{
+				string(models.ProxyBodySize): value,
+			})
+
+			if err := middleware.BodySize(ctx); err == nil {
+				t.Errorf("expected an error for %q", value)
+			}
+		}
+	})
+}
+
+func TestBodySize_clientBodyBufferSize(t *testing.T) {
+	t.Run("should merge client-body-buffer-size into the proxy-body-size middleware", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ProxyBodySize):        "8m",
+			string(models.ClientBodyBufferSize): "16k",
+		})
+
+		if err := middleware.BodySize(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.Middlewares) != 1 {
+			t.Fatalf("expected a single Buffering middleware, got %d", len(ctx.Result.Middlewares))
+		}
+
+		buffering := findMiddleware(t, ctx, "bodysize").Spec.Buffering
+		if buffering.MaxRequestBodyBytes != 8*1024*1024 || buffering.MemRequestBodyBytes != 16*1024 {
+			t.Errorf("unexpected buffering %+v", buffering)
+		}
+	})
+
+	t.Run("should generate a Buffering middleware for client-body-buffer-size alone", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ClientBodyBufferSize): "1m",
+		})
+
+		if err := middleware.BodySize(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		buffering := findMiddleware(t, ctx, "bodysize").Spec.Buffering
+		if buffering.MaxRequestBodyBytes != 0 || buffering.MemRequestBodyBytes != 1024*1024 {
+			t.Errorf("unexpected buffering %+v", buffering)
+		}
+	})
+
+	t.Run("should cap client-body-buffer-size to proxy-body-size", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ProxyBodySize):        "1m",
+			string(models.ClientBodyBufferSize): "2m",
+		})
+
+		if err := middleware.BodySize(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if findMiddleware(t, ctx, "bodysize").Spec.Buffering.MemRequestBodyBytes != 1024*1024 {
+			t.Errorf("expected memRequestBodyBytes to be capped")
+		}
+
+		if !hasWarning(ctx, "exceeds proxy-body-size") {
+			t.Errorf("expected a cap warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
@@ -3,6 +3,8 @@ package middleware
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
@@ -24,11 +26,14 @@ type unsupportedDirective struct {
 }
 
 type corsConfig struct {
-	OriginRegex  string
-	AllowHeaders []string
-	AllowMethods []string
-	AllowCreds   *bool
-	MaxAge       int64
+	OriginRegex   string
+	CaseSensitive bool
+	Origins       []string
+	AllowHeaders  []string
+	AllowMethods  []string
+	ExposeHeaders []string
+	AllowCreds    *bool
+	MaxAge        int64
 }
 
 type conditionalReturnConfig struct {
@@ -47,6 +52,10 @@ var unsupported = map[string]unsupportedDirective{
 	"gzip_types": {
 		Message: "gzip_types is not configurable in Traefik",
 	},
+	"gzip_static": {
+		Message: "gzip_static serves pre-compressed .gz files from disk which Traefik does not do; " +
+			"use the Compress middleware to compress responses on the fly",
+	},
 	"proxy_buffer_size": {
 		Message: "proxy_buffer_size is not supported in Traefik",
 	},
@@ -54,6 +63,32 @@ var unsupported = map[string]unsupportedDirective{
 		Enterprise: true,
 		Message:    "proxy_cache is not supported in Traefik OSS",
 	},
+	"proxy_cache_bypass": {
+		Enterprise: true,
+		Message:    "proxy_cache_bypass is not supported in Traefik OSS",
+	},
+	"proxy_no_cache": {
+		Enterprise: true,
+		Message:    "proxy_no_cache is not supported in Traefik OSS",
+	},
+	"add_trailer": {
+		Message: "add_trailer sets HTTP trailers which cannot be set via Traefik middleware; " +
+			"the backend must send the trailers itself",
+	},
+}
+
+// snippetBlockConverters recognise well-known multi-line NGINX idioms. Each one
+// converts the lines it understands and returns the remaining lines, which are
+// then handled by the CORS or generic snippet conversion.
+var snippetBlockConverters = []func(configs.Context, []string) []string{
+	convertMapBlocks,
+	convertSchemeRedirect,
+	convertHostRedirect,
+	convertRewriteRedirect,
+	convertLimitExcept,
+	convertRefererCheck,
+	convertInlineBasicAuth,
+	convertBrotli,
 }
 
 /* ---------------- CONFIGURATION SNIPPET ---------------- */
@@ -76,6 +111,36 @@ func ConfigurationSnippets(ctx configs.Context) error {
 		return nil
 	}
 
+	before := snippetOutputOf(ctx)
+
+	for _, convertBlock := range snippetBlockConverters {
+		lines = convertBlock(ctx, lines)
+	}
+
+	if len(lines) == 0 {
+		reportSnippet(ctx, nil, before)
+
+		return nil
+	}
+
+	// `set $cors` flag CORS idiom
+	if isCORSFlagSnippet(lines) {
+		cfg, err := parseConditionalCORSSnippet(lines)
+		if err != nil {
+			ctx.Result.Warnings = append(ctx.Result.Warnings,
+				"failed to parse $cors flag CORS snippet; skipped",
+			)
+
+			return err
+		}
+
+		emitCORSMiddleware(ctx, cfg)
+
+		ctx.ReportConverted(ann)
+
+		return nil
+	}
+
 	// 🔒 Conditional CORS handling
 	if isConditionalCORSSnippet(lines) {
 		cfg, err := parseConditionalCORSSnippet(lines)
@@ -90,6 +155,15 @@ func ConfigurationSnippets(ctx configs.Context) error {
 		emitCORSMiddleware(ctx, cfg)
 
 		if cr := parseConditionalReturn(lines); cr != nil {
+			if isPreflightReturn(cr) {
+				ctx.Result.Notes = append(ctx.Result.Notes,
+					"the OPTIONS preflight block ('return 204') of the CORS snippet was not converted; "+
+						"Traefik CORS middleware answers preflight requests automatically",
+				)
+
+				return nil
+			}
+
 			if err = emitConditionalReturnPlugin(ctx, cr); err != nil {
 				return err
 			}
@@ -98,16 +172,59 @@ func ConfigurationSnippets(ctx configs.Context) error {
 		return nil
 	}
 
-	convertGenericSnippet(ctx, lines)
+	if cfg, rest, found := parseUnconditionalCORSSnippet(lines); found {
+		emitCORSMiddleware(ctx, cfg)
+
+		lines = rest
+	}
+
+	dropped := make([]string, 0)
+
+	if len(lines) > 0 {
+		dropped = convertGenericSnippet(ctx, lines)
+	}
 
-	ctx.ReportConverted(ann)
+	reportSnippet(ctx, dropped, before)
 
 	return nil
 }
 
+// snippetOutput counts what the conversion has produced so far, the difference
+// tells whether a snippet generated Traefik objects or only guidance.
+type snippetOutput struct {
+	objects  int
+	warnings int
+}
+
+func snippetOutputOf(ctx configs.Context) snippetOutput {
+	return snippetOutput{
+		objects:  len(ctx.Result.Middlewares) + len(ctx.Result.ServersTransports),
+		warnings: len(ctx.Result.Warnings),
+	}
+}
+
+// reportSnippet reports the configuration-snippet as skipped when some of its
+// directives were dropped, so that strict mode catches the partial conversion, and
+// as a warning when it only produced guidance such as for limit_except.
+func reportSnippet(ctx configs.Context, dropped []string, before snippetOutput) {
+	ann := string(models.ConfigurationSnippet)
+	after := snippetOutputOf(ctx)
+
+	switch {
+	case len(dropped) > 0:
+		ctx.ReportSkipped(ann, "directives without a Traefik equivalent were not converted: "+strings.Join(dropped, "; "))
+	case after.objects == before.objects && after.warnings > before.warnings:
+		ctx.ReportWarning(ann, strings.Join(ctx.Result.Warnings[before.warnings:], "; "))
+	default:
+		ctx.ReportConverted(ann)
+	}
+}
+
 /* ---------------- Generic snippet handling ---------------- */
 
-func convertGenericSnippet(ctx configs.Context, lines []string) {
+// convertGenericSnippet converts the snippet directive by directive and returns the
+// directives which were dropped.
+func convertGenericSnippet(ctx configs.Context, lines []string) []string {
 	const (
 		reqHeadersCount  = 4
 		respHeadersCount = 8
@@ -118,6 +235,15 @@ func convertGenericSnippet(ctx configs.Context, lines []string) {
 	respHeaders := make(map[string]string, respHeadersCount)
 	warnings := make([]string, 0, warningsCount)
 
+	websocket := hasWebsocketUpgradeHeaders(lines)
+	errorPages := make([]errorPage, 0)
+	subFilters := make([]string, 0)
+	allows := make([]string, 0)
+	denyAll := false
+	interceptErrors := ""
+	trustedIPs := make([]string, 0)
+	dropped := make([]string, 0)
+
 	for _, raw := range lines {
 		line := strings.TrimSpace(raw)
 		if line == "" {
@@ -130,6 +256,12 @@ func convertGenericSnippet(ctx configs.Context, lines []string) {
 		case "add_header", "more_set_headers":
 			if k, v, ok := parseResponseHeader(line); ok {
 				respHeaders[k] = v
+
+				if v == "" {
+					warnings = append(warnings,
+						"response header "+k+" is set to an empty value; Traefik treats this as removal of the header",
+					)
+				}
 			} else {
 				warnings = append(warnings,
 					"failed to parse header directive: "+line,
@@ -137,9 +269,37 @@ func convertGenericSnippet(ctx configs.Context, lines []string) {
 			}
 
 		case "proxy_set_header":
-			key, val := parseProxySetHeader(line)
-			if key != "" {
-				reqHeaders[key] = val
+			key, val, ok := parseProxySetHeader(line)
+			if !ok {
+				warnings = append(warnings,
+					"failed to parse proxy_set_header directive (expected a header name and a value): "+line,
+				)
+
+				continue
+			}
+
+			if websocket && isWebsocketUpgradeHeader(key, val) {
+				continue
+			}
+
+			if strings.EqualFold(key, "Host") && !keepHostHeader(ctx, val, &warnings) {
+				continue
+			}
+
+			if isRedundantForwardedHeader(key, val) {
+				ctx.Result.Notes = append(ctx.Result.Notes,
+					"proxy_set_header "+key+" "+val+" is redundant; Traefik sets the "+key+" header automatically",
+				)
+
+				continue
+			}
+
+			reqHeaders[key] = val
+
+			if val == "" {
+				warnings = append(warnings,
+					"proxy_set_header clears request header "+key+"; Traefik treats the empty value as removal of the header",
+				)
 			}
 
 			if strings.Contains(val, "$") {
@@ -148,31 +308,154 @@ func convertGenericSnippet(ctx configs.Context, lines []string) {
 				)
 			}
 
-		case "gzip", "gzip_comp_level", "gzip_types", "proxy_buffer_size", "proxy_cache":
+		case "keepalive_timeout", "keepalive_requests":
+			warnings = append(warnings, keepaliveGuidance(ctx, line))
+
+		case "proxy_connect_timeout", "proxy_read_timeout", "proxy_send_timeout":
+			msg, err := applyTimeout(ctx, directive(lower), strings.Join(directiveArgs(line), " "))
+
+			switch {
+			case err != nil:
+				warnings = append(warnings, err.Error())
+			case msg != "":
+				warnings = append(warnings, msg)
+			}
+
+		case "set_real_ip_from":
+			trustedIPs = append(trustedIPs, directiveArgs(line)...)
+
+		case "large_client_header_buffers":
+			warnings = append(warnings, headerBuffersGuidance(ctx, line))
+
+		case "resolver", "resolver_timeout":
+			warnings = append(warnings, resolverGuidance(line))
+
+		case "satisfy":
+			if strings.EqualFold(firstArg(strings.Join(directiveArgs(line), " ")), "any") {
+				warnings = append(warnings, "configuration-snippet: "+satisfyAnyWarning)
+			}
+
+		case "error_page":
+			if page, ok := parseErrorPage(line); ok {
+				errorPages = append(errorPages, page)
+			} else {
+				warnings = append(warnings,
+					"failed to parse error_page directive: "+line,
+				)
+			}
+
+		case "proxy_intercept_errors":
+			interceptErrors = strings.ToLower(firstArg(strings.Join(directiveArgs(line), " ")))
+
+		case "proxy_pass_request_headers":
+			if strings.EqualFold(firstArg(strings.Join(directiveArgs(line), " ")), "off") {
+				warnings = append(warnings, proxyPassRequestHeadersOffWarning)
+			}
+
+		case "proxy_pass_header":
+			ctx.Result.Notes = append(ctx.Result.Notes,
+				"proxy_pass_header "+strings.Join(directiveArgs(line), " ")+" is a no-op in Traefik; "+
+					"Traefik passes all backend response headers to the client by default",
+			)
+
+		case "proxy_ignore_headers":
+			warnings = append(warnings,
+				"proxy_ignore_headers "+strings.Join(directiveArgs(line), " ")+" has no effect in Traefik; "+
+					"Traefik does not cache responses or process X-Accel-* headers, the listed headers are passed through as-is",
+			)
+
+		case "allow":
+			if source := firstArg(strings.Join(directiveArgs(line), " ")); strings.EqualFold(source, "all") {
+				warnings = append(warnings, "allow all does not restrict access and was not converted")
+			} else {
+				allows = append(allows, source)
+			}
+
+		case "deny":
+			if source := firstArg(strings.Join(directiveArgs(line), " ")); strings.EqualFold(source, "all") {
+				denyAll = true
+			} else {
+				warnings = append(warnings,
+					"deny "+source+" cannot be converted; Traefik IPAllowList only lists allowed sources, "+
+						"restrict access with allow directives followed by 'deny all' instead",
+				)
+			}
+
+		case "internal":
+			warnings = append(warnings,
+				"internal marks the location as reachable only through internal redirects, which cannot be expressed in Traefik; "+
+					"do not expose a route for these paths if they must not be reachable externally",
+			)
+
+		case "sub_filter":
+			warnings = append(warnings, subFilterWarning(line))
+			subFilters = append(subFilters, line)
+
+		case "gzip", "gzip_comp_level", "gzip_types", "gzip_static", "proxy_buffer_size",
+			"proxy_cache", "proxy_cache_bypass", "proxy_no_cache", "add_trailer":
 			if u, ok := unsupported[directive(lower)]; ok {
 				warnUnsupported(&warnings, u)
 			}
 
+			dropped = append(dropped, line)
+
 		default:
 			warnings = append(warnings,
 				"unsupported directive in configuration-snippet was ignored: "+line,
 			)
+
+			dropped = append(dropped, line)
 		}
 	}
 
-	ctx.Result.Warnings = append(ctx.Result.Warnings, warnings...)
+	if websocket {
+		warnings = append(warnings,
+			"proxy_set_header Upgrade/Connection websocket headers were not converted; "+
+				"Traefik handles websocket upgrades out of the box",
+		)
+	}
 
-	if len(reqHeaders) == 0 && len(respHeaders) == 0 {
-		return
+	if len(errorPages) > 0 {
+		convertErrorPages(ctx, errorPages, &warnings)
 	}
 
-	ctx.Result.Middlewares = append(
-		ctx.Result.Middlewares,
-		newHeadersMiddleware(ctx, "configuration-snippet", &dynamic.Headers{
+	if len(trustedIPs) > 0 {
+		warnings = append(warnings, trustedIPsGuidance(ctx, trustedIPs)...)
+	}
+
+	if interceptErrors != "" {
+		interceptErrorsGuidance(ctx, interceptErrors, len(errorPages) > 0, &warnings)
+	}
+
+	if len(allows) > 0 {
+		convertAllowList(ctx, allows, denyAll, &warnings)
+	} else if denyAll {
+		warnings = append(warnings,
+			"deny all without allow directives blocks every request and was not converted",
+		)
+	}
+
+	if len(subFilters) > 0 && emitPluginPlaceholders(ctx) {
+		subFilterPlaceholder(ctx, subFilters, &warnings)
+	}
+
+	if len(reqHeaders) > 0 || len(respHeaders) > 0 {
+		headers := &dynamic.Headers{
 			CustomRequestHeaders:  reqHeaders,
 			CustomResponseHeaders: respHeaders,
-		}),
-	)
+		}
+
+		applySecurityHeaders(headers, &warnings)
+
+		ctx.Result.Middlewares = append(
+			ctx.Result.Middlewares,
+			newHeadersMiddleware(ctx, "configuration-snippet", headers),
+		)
+	}
+
+	ctx.Result.Warnings = append(ctx.Result.Warnings, warnings...)
+
+	return dropped
 }
 
 /* ---------------- CORS handling ---------------- */
@@ -210,12 +493,13 @@ func isConditionalCORSSnippet(lines []string) bool {
 func parseConditionalCORSSnippet(lines []string) (*corsConfig, error) {
 	cfg := &corsConfig{}
 
-	origin, ok := extractOriginRegex(lines)
+	origin, caseSensitive, ok := extractOriginRegex(lines)
 	if !ok {
 		return nil, &errors.ConverterError{Message: "no origin regex found"}
 	}
 
 	cfg.OriginRegex = origin
+	cfg.CaseSensitive = caseSensitive
 
 	for _, raw := range lines {
 		line := strings.TrimSpace(raw)
@@ -223,13 +507,16 @@ func parseConditionalCORSSnippet(lines []string) (*corsConfig, error) {
 
 		switch {
 		case strings.Contains(lower, "access-control-allow-headers"):
-			cfg.AllowHeaders = splitCSV(extractQuotedHeaderValue(line))
+			cfg.AllowHeaders = splitCSV(extractCORSHeaderValue(line))
 
 		case strings.Contains(lower, "access-control-allow-methods"):
-			cfg.AllowMethods = splitCSV(extractQuotedHeaderValue(line))
+			cfg.AllowMethods = splitCSV(extractCORSHeaderValue(line))
+
+		case strings.Contains(lower, "access-control-expose-headers"):
+			cfg.ExposeHeaders = splitCSV(extractCORSHeaderValue(line))
 
 		case strings.Contains(lower, "access-control-allow-credentials"):
-			v := strings.ToLower(extractQuotedHeaderValue(line))
+			v := strings.ToLower(extractCORSHeaderValue(line))
 			if v == "true" || v == "false" {
 				b := v == "true"
 				cfg.AllowCreds = &b
@@ -238,6 +525,8 @@ func parseConditionalCORSSnippet(lines []string) (*corsConfig, error) {
 		case strings.Contains(lower, "access-control-max-age"):
 			if age := extractInt(line); age > 0 {
 				cfg.MaxAge = age
+			} else if age, err := strconv.ParseInt(extractCORSHeaderValue(line), 10, 64); err == nil && age > 0 {
+				cfg.MaxAge = age
 			}
 		}
 	}
@@ -251,22 +540,44 @@ func parseConditionalCORSSnippet(lines []string) (*corsConfig, error) {
 
 func emitCORSMiddleware(ctx configs.Context, cfg *corsConfig) {
 	headers := &dynamic.Headers{
-		AccessControlAllowMethods: cfg.AllowMethods,
-		AccessControlAllowHeaders: cfg.AllowHeaders,
-		AccessControlAllowOriginListRegex: []string{
-			cfg.OriginRegex,
-		},
-		AccessControlMaxAge: cfg.MaxAge,
+		AccessControlAllowMethods:    cfg.AllowMethods,
+		AccessControlAllowHeaders:    cfg.AllowHeaders,
+		AccessControlExposeHeaders:   cfg.ExposeHeaders,
+		AccessControlAllowOriginList: cfg.Origins,
+		AccessControlMaxAge:          cfg.MaxAge,
+	}
+
+	// literal-only alternations are emitted as a plain origin list, any other
+	// captured alternation (https://a\.com|https://b\.com) is passed through as
+	// a single entry, Traefik accepts a match of any entry
+	if cfg.OriginRegex != "" {
+		if origins, ok := literalOrigins(cfg.OriginRegex); ok {
+			headers.AccessControlAllowOriginList = append(headers.AccessControlAllowOriginList, origins...)
+		} else if err := validateOriginRegex(cfg.OriginRegex); err == nil {
+			headers.AccessControlAllowOriginListRegex = []string{cfg.OriginRegex}
+		} else {
+			ctx.Result.Warnings = append(ctx.Result.Warnings, err.Error())
+		}
 	}
 
 	if cfg.AllowCreds != nil {
 		headers.AccessControlAllowCredentials = *cfg.AllowCreds
 	}
 
-	ctx.Result.Middlewares = append(
-		ctx.Result.Middlewares,
-		newHeadersMiddleware(ctx, "cors", headers),
-	)
+	if !mergeAnnotationCORS(ctx, headers, cfg.AllowCreds != nil) {
+		ctx.Result.Middlewares = append(
+			ctx.Result.Middlewares,
+			newHeadersMiddleware(ctx, "cors", headers),
+		)
+	}
+
+	if cfg.OriginRegex == "" {
+		ctx.Result.Warnings = append(ctx.Result.Warnings,
+			"unconditional NGINX CORS headers were converted to Traefik CORS middleware",
+		)
+
+		return
+	}
 
 	if len(cfg.AllowHeaders) == 0 || len(cfg.AllowMethods) == 0 {
 		ctx.Result.Warnings = append(ctx.Result.Warnings,
@@ -274,6 +585,13 @@ func emitCORSMiddleware(ctx configs.Context, cfg *corsConfig) {
 		)
 	}
 
+	if cfg.CaseSensitive {
+		ctx.Result.Warnings = append(ctx.Result.Warnings,
+			"conditional CORS snippet matches $http_origin case-sensitively (~); the origin regex was kept as-is "+
+				"since Traefik evaluates accessControlAllowOriginListRegex case-sensitively by default",
+		)
+	}
+
 	ctx.Result.Warnings = append(ctx.Result.Warnings,
 		"conditional NGINX CORS logic was converted to Traefik CORS middleware",
 	)
@@ -352,6 +670,12 @@ func parseConditionalReturn(lines []string) *conditionalReturnConfig {
 	return nil
 }
 
+// isPreflightReturn reports whether the conditional return only short-circuits
+// CORS preflight requests, which the Traefik CORS middleware already answers.
+func isPreflightReturn(cfg *conditionalReturnConfig) bool {
+	return cfg.Method == "OPTIONS" && cfg.StatusCode == http.StatusNoContent
+}
+
 func emitConditionalReturnPlugin(ctx configs.Context, cfg *conditionalReturnConfig) error {
 	pluginCfg := map[string]any{
 		"rules": []map[string]any{
@@ -396,6 +720,20 @@ func parseAddHeaderNormalized(line string) (string, string, bool) {
 		return "", "", false
 	}
 
+	// more_set_headers "Header-Name: Header Value"
+	if strings.HasPrefix(lower, "more_set_headers") {
+		key, val, ok := parseResponseHeader(line)
+		if !ok || key == "" || val == "" {
+			return "", "", false
+		}
+
+		if strings.Contains(val, "$http_origin") {
+			val = "*"
+		}
+
+		return key, val, true
+	}
+
 	// Remove directive name
 	fields := strings.Fields(line)
 	if len(fields) < 3 {
@@ -474,22 +812,144 @@ func parseAddHeaderNormalized(line string) (string, string, bool) {
 func splitLines(s string) []string {
 	out := make([]string, 0)
 
-	for _, l := range strings.Split(s, "\n") {
-		if t := strings.TrimSpace(l); t != "" {
-			out = append(out, t)
+	// normalise CRLF and lone CR line endings of Windows/classic Mac authored manifests
+	s = strings.ReplaceAll(strings.ReplaceAll(s, "\r\n", "\n"), "\r", "\n")
+
+	for _, l := range joinQuotedLines(strings.Split(s, "\n")) {
+		for _, part := range splitDirectives(stripComment(l)) {
+			if t := normalizeTerminator(part); t != "" {
+				out = append(out, t)
+			}
 		}
 	}
 
 	return out
 }
 
+// normalizeTerminator trims a directive and collapses messy terminators such as
+// `bar ;` or `bar;;` into a single trailing semicolon. A stray `;` on its own is
+// dropped.
+func normalizeTerminator(part string) string {
+	trimmed := strings.TrimRight(part, "; \t")
+	if trimmed == strings.TrimRight(part, " \t") {
+		return strings.TrimSpace(trimmed)
+	}
+
+	if trimmed = strings.TrimSpace(trimmed); trimmed == "" {
+		return ""
+	}
+
+	return trimmed + ";"
+}
+
+// joinQuotedLines joins physical lines of a quoted value spanning several lines,
+// e.g. a Content-Security-Policy written one policy per line, into one line.
+// Lines are only joined up to the line closing the quote; a quote which is never
+// closed leaves the lines untouched.
+func joinQuotedLines(lines []string) []string {
+	out := make([]string, 0, len(lines))
+	pending := make([]string, 0)
+
+	for _, line := range lines {
+		pending = append(pending, line)
+
+		joined := strings.TrimSpace(pending[0])
+		for _, next := range pending[1:] {
+			joined += " " + strings.TrimSpace(next)
+		}
+
+		if hasOpenQuote(stripComment(joined)) {
+			continue
+		}
+
+		out = append(out, joined)
+		pending = pending[:0]
+	}
+
+	return append(out, pending...)
+}
+
+// hasOpenQuote reports whether the line ends inside a quoted string.
+func hasOpenQuote(line string) bool {
+	var quote rune
+
+	for index, char := range line {
+		switch {
+		case quote != 0:
+			if char == quote && (index == 0 || line[index-1] != '\\') {
+				quote = 0
+			}
+		case char == '"' || char == '\'':
+			quote = char
+		}
+	}
+
+	return quote != 0
+}
+
+// splitDirectives splits a physical snippet line holding several directives,
+// e.g. `add_header X-A a; add_header X-B b;`, on unquoted semicolons. Conditions
+// `( ... )` and blocks `{ ... }` are never split, so the block parsers still see
+// a one-line `if (...) { ... }` as a whole.
+func splitDirectives(line string) []string {
+	var quote rune
+
+	parts := make([]string, 0, 1)
+	depth, start := 0, 0
+
+	for index, char := range line {
+		switch {
+		case quote != 0:
+			if char == quote && (index == 0 || line[index-1] != '\\') {
+				quote = 0
+			}
+		case char == '"' || char == '\'':
+			quote = char
+		case char == '(' || char == '{':
+			depth++
+		case char == ')' || char == '}':
+			if depth > 0 {
+				depth--
+			}
+		case char == ';' && depth == 0:
+			parts = append(parts, line[start:index+1])
+			start = index + 1
+		}
+	}
+
+	return append(parts, line[start:])
+}
+
+// stripComment removes a trailing `# comment` from a snippet line. As in NGINX,
+// a `#` only starts a comment at the beginning of a token and never inside a
+// quoted string.
+func stripComment(line string) string {
+	var quote rune
+
+	for index, char := range line {
+		switch {
+		case quote != 0:
+			if char == quote && (index == 0 || line[index-1] != '\\') {
+				quote = 0
+			}
+		case char == '"' || char == '\'':
+			quote = char
+		case char == '#' && (index == 0 || strings.ContainsRune(" \t;{}", rune(line[index-1]))):
+			return line[:index]
+		}
+	}
+
+	return line
+}
+
 func directive(line string) string {
 	fields := strings.Fields(line)
 	if len(fields) == 0 {
 		return ""
 	}
 
-	return fields[0]
+	// directives without arguments, e.g. `internal;`, carry the terminator
+	return strings.TrimSuffix(fields[0], ";")
 }
 
 func warnUnsupported(warnings *[]string, d unsupportedDirective) {
@@ -523,23 +983,34 @@ func newHeadersMiddleware(
 
 /* ---------------- Parsing helpers ---------------- */
 
-func parseProxySetHeader(line string) (string, string) {
-	line = strings.TrimSuffix(line, ";")
+func parseProxySetHeader(line string) (string, string, bool) {
+	line = strings.TrimSuffix(strings.TrimSpace(line), ";")
 	parts := strings.Fields(line)
 
 	const proxySetHeaderCount = 3
 
 	if len(parts) < proxySetHeaderCount {
-		return "", ""
+		return "", "", false
+	}
+
+	key := strings.Trim(parts[1], `"`)
+	val := strings.Join(parts[2:], " ")
+
+	// "" and '' clear the header
+	if val == `""` || val == `''` {
+		val = ""
 	}
 
-	return strings.Trim(parts[1], `"`), strings.Join(parts[2:], " ")
+	return key, val, key != ""
 }
 
 func parseResponseHeader(line string) (string, string, bool) {
 	line = strings.TrimSuffix(strings.TrimSpace(line), ";")
 
-	if strings.HasPrefix(line, "more_set_headers") {
+	// directive names are matched case-insensitively, as in convertGenericSnippet
+	name := strings.ToLower(directive(line))
+
+	if name == "more_set_headers" {
 		start := strings.Index(line, `"`)
 		end := strings.LastIndex(line, `"`)
 
@@ -557,36 +1028,129 @@ func parseResponseHeader(line string) (string, string, bool) {
 		return strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1]), true
 	}
 
-	const addHeaderCount = 3
+	const addHeaderCount = 2
+
+	if name == "add_header" {
+		// quoted names and multi word values must not be split on whitespace
+		args := quotedArgs(line)
+		if len(args) > addHeaderCount && args[len(args)-1] == "always" {
+			args = args[:len(args)-1]
+		}
 
-	if strings.HasPrefix(line, "add_header") {
-		fields := strings.Fields(line)
-		if len(fields) < addHeaderCount {
+		if len(args) < addHeaderCount || args[0] == "" {
 			return "", "", false
 		}
 
-		return strings.Trim(fields[1], `"`),
-			strings.Trim(strings.Join(fields[2:], " "), `"`),
-			true
+		return args[0], strings.Join(args[1:], " "), true
 	}
 
 	return "", "", false
 }
 
+// quotedArgs returns the directive arguments, keeping single or double quoted
+// arguments containing spaces together and stripping the quotes.
+func quotedArgs(line string) []string {
+	line = strings.TrimSuffix(strings.TrimSpace(line), ";")
+
+	args := make([]string, 0)
+
+	var (
+		current strings.Builder
+		quote   rune
+		quoted  bool
+	)
+
+	flush := func() {
+		if current.Len() > 0 || quoted {
+			args = append(args, current.String())
+		}
+
+		current.Reset()
+
+		quoted = false
+	}
+
+	for _, char := range line {
+		switch {
+		case quote != 0 && char == quote:
+			quote = 0
+		case quote != 0:
+			current.WriteRune(char)
+		case char == '"' || char == '\'':
+			quote = char
+			quoted = true
+		case char == ' ' || char == '\t':
+			flush()
+		default:
+			current.WriteRune(char)
+		}
+	}
+
+	flush()
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	return args[1:]
+}
+
 var originIfRe = regexp.MustCompile(
-	`\$http_origin\s+~\*\s+\((.+?)\)\s*\)`,
+	`\$http_origin\s+(~\*?)\s+\((.+?)\)\s*\)`,
 )
 
-func extractOriginRegex(lines []string) (string, bool) {
-	const originRegexCount = 2
+// extractOriginRegex returns the origin regex of the `if ($http_origin ~* (...))`
+// condition and whether it was matched case-sensitively with `~`.
+func extractOriginRegex(lines []string) (string, bool, bool) {
+	const originRegexCount = 3
 
 	for _, l := range lines {
 		if m := originIfRe.FindStringSubmatch(l); len(m) == originRegexCount {
-			return m[1], true
+			return m[2], m[1] == "~", true
+		}
+	}
+
+	return "", false, false
+}
+
+// validateOriginRegex checks that a captured origin regex compiles with the Go
+// regexp syntax used by Traefik, so that no unapplyable middleware is emitted.
+func validateOriginRegex(origin string) error {
+	if _, err := regexp.Compile(origin); err != nil {
+		return &errors.ConverterError{Message: fmt.Sprintf(
+			"origin regex %q from the CORS snippet is not a valid Go regex (%v) and was skipped; "+
+				"add an anchored pattern such as ^https://example\\.com$ to accessControlAllowOriginListRegex manually",
+			origin, err,
+		)}
+	}
+
+	return nil
+}
+
+// literalOrigins splits an origin regex alternation into literal origins. It
+// returns false as soon as one alternative uses a regex construct other than
+// the ^/$ anchors and escaped dots.
+func literalOrigins(origin string) ([]string, bool) {
+	alternatives := strings.Split(origin, "|")
+	origins := make([]string, 0, len(alternatives))
+
+	for _, alternative := range alternatives {
+		alternative = strings.TrimSuffix(strings.TrimPrefix(alternative, "^"), "$")
+
+		// an unescaped dot matches any character
+		if strings.Contains(strings.ReplaceAll(alternative, `\.`, ""), ".") {
+			return nil, false
+		}
+
+		literal := strings.ReplaceAll(alternative, `\.`, ".")
+		if literal == "" || strings.ContainsAny(literal, `\^$*+?()[]{}|`) {
+			return nil, false
 		}
+
+		origins = append(origins, literal)
 	}
 
-	return "", false
+	return origins, true
 }
 
 func extractQuotedHeaderValue(line string) string {
@@ -619,6 +1183,20 @@ func extractQuotedHeaderValue(line string) string {
 	return values[len(values)-1]
 }
 
+// extractCORSHeaderValue returns the value of an `add_header` or
+// `more_set_headers "Name: value"` CORS header line.
+func extractCORSHeaderValue(line string) string {
+	value := extractQuotedHeaderValue(line)
+
+	if strings.HasPrefix(strings.ToLower(strings.TrimSpace(line)), "more_set_headers") {
+		if _, v, found := strings.Cut(value, ":"); found {
+			return strings.TrimSpace(v)
+		}
+	}
+
+	return value
+}
+
 func splitCSV(v string) []string {
 	out := make([]string, 0)
 
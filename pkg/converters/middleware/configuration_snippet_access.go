@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+)
+
+/* ---------------- Access control handling ---------------- */
+
+var invalidRefererIfRe = regexp.MustCompile(`(?i)^if\s*\(\s*\$invalid_referer\s*\)\s*\{\s*(.*)$`)
+
+// convertRefererCheck recognises hotlink protection built from `valid_referers`
+// and an `if ($invalid_referer) { ... }` block. Traefik cannot check the Referer
+// header natively, so the whole construct is reported as a single warning.
+// It returns the snippet lines that were not consumed.
+func convertRefererCheck(ctx configs.Context, lines []string) []string {
+	rest := make([]string, 0, len(lines))
+	referers := make([]string, 0)
+	found := false
+
+	for index := 0; index < len(lines); index++ {
+		line := lines[index]
+
+		if directive(strings.ToLower(line)) == "valid_referers" {
+			referers = append(referers, strings.Fields(strings.TrimSuffix(line, ";"))[1:]...)
+			found = true
+
+			continue
+		}
+
+		if match := invalidRefererIfRe.FindStringSubmatch(line); match != nil {
+			if _, end, ok := collectBlock(lines, index, match[1]); ok {
+				found = true
+				index = end
+
+				continue
+			}
+		}
+
+		rest = append(rest, line)
+	}
+
+	if !found {
+		return rest
+	}
+
+	ctx.Result.Warnings = append(ctx.Result.Warnings, fmt.Sprintf(
+		"configuration-snippet implements referer checking (valid_referers: %s) which Traefik cannot do natively; "+
+			"use a ForwardAuth service or a Traefik plugin to validate the Referer header",
+		strings.Join(referers, " "),
+	))
+
+	return rest
+}
+
+// convertInlineBasicAuth recognises `auth_basic` / `auth_basic_user_file` in a
+// snippet. The user file lives on the NGINX filesystem and cannot be read, so
+// the directives are replaced by a pointer to the annotation based BasicAuth path.
+// It returns the snippet lines that were not consumed.
+func convertInlineBasicAuth(ctx configs.Context, lines []string) []string {
+	rest := make([]string, 0, len(lines))
+
+	var realm, userFile string
+
+	found := false
+
+	for _, line := range lines {
+		fields := strings.Fields(strings.TrimSuffix(line, ";"))
+
+		switch directive(strings.ToLower(line)) {
+		case "auth_basic":
+			if len(fields) > 1 {
+				realm = strings.Trim(strings.Join(fields[1:], " "), `"'`)
+			}
+
+			found = true
+		case "auth_basic_user_file":
+			if len(fields) > 1 {
+				userFile = fields[1]
+			}
+
+			found = true
+		default:
+			rest = append(rest, line)
+		}
+	}
+
+	if !found {
+		return rest
+	}
+
+	ctx.Result.Warnings = append(ctx.Result.Warnings, fmt.Sprintf(
+		"configuration-snippet configures inline basic auth (realm %q, user file %q) which cannot be converted; "+
+			"store the htpasswd users in a secret and use the 'auth-type: basic', 'auth-secret' and 'auth-realm' "+
+			"annotations to generate a Traefik BasicAuth middleware",
+		realm, userFile,
+	))
+
+	return rest
+}
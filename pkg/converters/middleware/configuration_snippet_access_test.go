@@ -0,0 +1,52 @@
+package middleware_test
+
+import (
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestConfigurationSnippets_validReferers(t *testing.T) {
+	t.Run("should emit a single referer checking warning", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `valid_referers none blocked server_names *.example.com;
+if ($invalid_referer) {
+  return 403;
+}`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !hasWarning(ctx, "valid_referers: none blocked server_names *.example.com") {
+			t.Errorf("expected a referer checking warning, got %v", ctx.Result.Warnings)
+		}
+
+		if len(ctx.Result.Warnings) != 1 {
+			t.Errorf("expected exactly one warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
+
+func TestConfigurationSnippets_inlineBasicAuth(t *testing.T) {
+	t.Run("should point inline basic auth to the BasicAuth annotations", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `auth_basic "Restricted Area";
+auth_basic_user_file /etc/nginx/htpasswd;`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !hasWarning(ctx, `realm "Restricted Area"`) {
+			t.Errorf("expected the realm in the warning, got %v", ctx.Result.Warnings)
+		}
+
+		if !hasWarning(ctx, "'auth-type: basic'") {
+			t.Errorf("expected a pointer to the auth annotations, got %v", ctx.Result.Warnings)
+		}
+	})
+}
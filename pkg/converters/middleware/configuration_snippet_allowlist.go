@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	traefik "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+/* ---------------- allow / deny handling ---------------- */
+
+// allowListDenyNote explains that IPAllowList keeps the `deny all;` of the snippet.
+const allowListDenyNote = "requests from sources outside of the sourceRange are denied by default, " +
+	"matching the NGINX 'deny all' behavior"
+
+// convertAllowList turns the `allow <cidr>;` directives of a snippet followed by
+// `deny all;` into an IPAllowList middleware. The sources are merged into the
+// IPAllowList of the whitelist-source-range annotation when present, since NGINX
+// evaluates both lists together.
+func convertAllowList(ctx configs.Context, allows []string, denyAll bool, warnings *[]string) {
+	if !denyAll {
+		*warnings = append(*warnings,
+			"allow directives without a trailing 'deny all' do not restrict access in NGINX; "+
+				"no IPAllowList was generated for: "+strings.Join(allows, ", "),
+		)
+
+		return
+	}
+
+	ranges, invalid := parseSourceRanges(allows)
+
+	if len(invalid) > 0 {
+		*warnings = append(*warnings,
+			"allow entries are not valid IPs or CIDRs and were dropped: "+strings.Join(invalid, ", "),
+		)
+	}
+
+	if len(ranges) == 0 {
+		return
+	}
+
+	for _, mw := range ctx.Result.Middlewares {
+		if mw.GetName() != mwName(ctx, "whitelist-source-range") || mw.Spec.IPAllowList == nil {
+			continue
+		}
+
+		for _, source := range ranges {
+			if !slices.Contains(mw.Spec.IPAllowList.SourceRange, source) {
+				mw.Spec.IPAllowList.SourceRange = append(mw.Spec.IPAllowList.SourceRange, source)
+			}
+		}
+
+		*warnings = append(*warnings,
+			"configuration-snippet allow directives were merged into the whitelist-source-range IPAllowList",
+		)
+
+		return
+	}
+
+	ctx.Result.Middlewares = append(ctx.Result.Middlewares, &traefik.Middleware{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: traefik.SchemeGroupVersion.String(),
+			Kind:       "Middleware",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mwName(ctx, "ip-allowlist"),
+			Namespace: ctx.Namespace,
+			Annotations: map[string]string{
+				NoteAnnotation: allowListDenyNote,
+			},
+		},
+		Spec: traefik.MiddlewareSpec{
+			IPAllowList: &dynamic.IPAllowList{
+				SourceRange: ranges,
+			},
+		},
+	})
+}
@@ -0,0 +1,93 @@
+package middleware_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestConfigurationSnippets_allowList(t *testing.T) {
+	t.Run("should convert allow and deny all directives to an IPAllowList", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `allow 10.0.0.0/8;
+allow 192.168.1.10;
+deny all;`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		allowList := findMiddleware(t, ctx, "ip-allowlist").Spec.IPAllowList
+
+		expected := []string{"10.0.0.0/8", "192.168.1.10"}
+		if allowList == nil || !reflect.DeepEqual(allowList.SourceRange, expected) {
+			t.Fatalf("expected source range %v, got %+v", expected, allowList)
+		}
+
+		if len(ctx.Result.Warnings) != 0 {
+			t.Errorf("expected no warnings, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should warn about deny entries other than all", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `deny 10.1.0.0/16;
+allow 10.0.0.0/8;
+deny all;`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !hasWarning(ctx, "deny 10.1.0.0/16 cannot be converted") {
+			t.Errorf("expected deny warning, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should merge into the whitelist-source-range IPAllowList", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.WhitelistSourceRange): "10.0.0.0/8",
+			string(models.ConfigurationSnippet): `allow 172.16.0.0/12;
+deny all;`,
+		})
+
+		middleware.WhitelistSourceRange(ctx)
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.Middlewares) != 1 {
+			t.Fatalf("expected a single IPAllowList middleware, got %d middlewares", len(ctx.Result.Middlewares))
+		}
+
+		allowList := findMiddleware(t, ctx, "whitelist-source-range").Spec.IPAllowList
+
+		expected := []string{"10.0.0.0/8", "172.16.0.0/12"}
+		if !reflect.DeepEqual(allowList.SourceRange, expected) {
+			t.Errorf("expected source range %v, got %v", expected, allowList.SourceRange)
+		}
+	})
+
+	t.Run("should not restrict access for allow directives without deny all", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `allow 10.0.0.0/8;`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.Middlewares) != 0 {
+			t.Errorf("expected no IPAllowList middleware, got %d middlewares", len(ctx.Result.Middlewares))
+		}
+
+		if !hasWarning(ctx, "no IPAllowList was generated for: 10.0.0.0/8") {
+			t.Errorf("expected missing deny all warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+/* ---------------- $cors flag CORS handling ---------------- */
+
+var (
+	corsFlagSetRe    = regexp.MustCompile(`(?i)^set\s+\$cors\s+.*;$`)
+	corsFlagIfRe     = regexp.MustCompile(`(?i)^if\s*\(\s*\$cors\s*=\s*.+\)\s*\{$`)
+	corsOriginIfRe   = regexp.MustCompile(`(?i)^if\s*\(\s*\$http_origin\s+~\*?\s+.+\)\s*\{$`)
+	corsMethodIfRe   = regexp.MustCompile(`(?i)^if\s*\(\s*\$request_method\s*=\s*.+\)\s*\{$`)
+	corsFlagHeaderRe = regexp.MustCompile(
+		`(?i)^(add_header|more_set_headers)\s+["']?(access-control-|content-type|content-length)`,
+	)
+	corsFlagReturnRe = regexp.MustCompile(`(?i)^return\s+204\s*;$`)
+)
+
+// isCORSFlagSnippet recognises the well-known CORS idiom which sets a `$cors`
+// flag inside the origin-if and adds the CORS headers in an `if ($cors = ...)`
+// block. It is deliberately conservative: every line must belong to the idiom.
+func isCORSFlagSnippet(lines []string) bool {
+	var hasSet, hasFlagIf, hasOriginIf bool
+
+	for _, raw := range lines {
+		line := strings.TrimSpace(raw)
+
+		switch {
+		case corsFlagSetRe.MatchString(line):
+			hasSet = true
+		case corsFlagIfRe.MatchString(line):
+			hasFlagIf = true
+		case corsOriginIfRe.MatchString(line):
+			hasOriginIf = true
+		case corsMethodIfRe.MatchString(line),
+			corsFlagHeaderRe.MatchString(line),
+			corsFlagReturnRe.MatchString(line),
+			line == "}":
+		default:
+			return false
+		}
+	}
+
+	return hasSet && hasFlagIf && hasOriginIf
+}
+
+/* ---------------- Unconditional CORS handling ---------------- */
+
+// parseUnconditionalCORSSnippet recognises CORS response headers which are set
+// for every request, e.g. `add_header Access-Control-Allow-Origin "*" always;`.
+// The literal origins are kept so that they can be emitted as a Traefik CORS
+// middleware instead of raw response headers. It returns the lines which are
+// not CORS headers, and false when the snippet sets no Access-Control-Allow-Origin.
+func parseUnconditionalCORSSnippet(lines []string) (*corsConfig, []string, bool) {
+	cfg := &corsConfig{}
+	rest := make([]string, 0, len(lines))
+
+	for _, line := range lines {
+		if strings.Contains(strings.ToLower(line), "if ($http_origin") {
+			return nil, lines, false
+		}
+
+		key, val, ok := parseAddHeaderNormalized(line)
+		if !ok || !applyCORSHeader(cfg, key, val) {
+			rest = append(rest, line)
+		}
+	}
+
+	if len(cfg.Origins) == 0 {
+		return nil, lines, false
+	}
+
+	if len(cfg.AllowMethods) == 0 {
+		cfg.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
+	}
+
+	return cfg, rest, true
+}
+
+// applyCORSHeader stores a single Access-Control-* response header in the
+// CORS config. It returns false for any other header.
+func applyCORSHeader(cfg *corsConfig, key, val string) bool {
+	switch strings.ToLower(key) {
+	case "access-control-allow-origin":
+		cfg.Origins = append(cfg.Origins, val)
+
+	case "access-control-allow-methods":
+		cfg.AllowMethods = splitCSV(val)
+
+	case "access-control-allow-headers":
+		cfg.AllowHeaders = splitCSV(val)
+
+	case "access-control-expose-headers":
+		cfg.ExposeHeaders = splitCSV(val)
+
+	case "access-control-allow-credentials":
+		if creds, err := strconv.ParseBool(val); err == nil {
+			cfg.AllowCreds = &creds
+		}
+
+	case "access-control-max-age":
+		if age, err := strconv.ParseInt(val, 10, 64); err == nil && age > 0 {
+			cfg.MaxAge = age
+		}
+
+	default:
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+/* ---------------- CORS merge ---------------- */
+
+// mergeAnnotationCORS merges the CORS headers of a configuration-snippet into the
+// CORS middleware generated from the cors-* annotations, so a single CORS
+// middleware is emitted. The annotations win and the snippet fills the gaps.
+// It reports whether a middleware of the annotations was found.
+func mergeAnnotationCORS(ctx configs.Context, snippet *dynamic.Headers, snippetSetsCreds bool) bool {
+	var annotation *dynamic.Headers
+
+	for _, mw := range ctx.Result.Middlewares {
+		if mw.GetName() == mwName(ctx, "cors") && mw.Spec.Headers != nil {
+			annotation = mw.Spec.Headers
+
+			break
+		}
+	}
+
+	if annotation == nil {
+		return false
+	}
+
+	conflicts := make([]string, 0)
+
+	mergeList := func(name string, dst *[]string, src []string) {
+		switch {
+		case len(src) == 0:
+		case len(*dst) == 0:
+			*dst = src
+		case !slices.Equal(*dst, src):
+			conflicts = append(conflicts, name)
+		}
+	}
+
+	mergeList("Access-Control-Allow-Methods", &annotation.AccessControlAllowMethods, snippet.AccessControlAllowMethods)
+	mergeList("Access-Control-Allow-Headers", &annotation.AccessControlAllowHeaders, snippet.AccessControlAllowHeaders)
+	mergeList("Access-Control-Expose-Headers", &annotation.AccessControlExposeHeaders, snippet.AccessControlExposeHeaders)
+
+	// the origin list and regex together define the allowed origins.
+	switch {
+	case len(snippet.AccessControlAllowOriginList) == 0 && len(snippet.AccessControlAllowOriginListRegex) == 0:
+	case len(annotation.AccessControlAllowOriginList) == 0 && len(annotation.AccessControlAllowOriginListRegex) == 0:
+		annotation.AccessControlAllowOriginList = snippet.AccessControlAllowOriginList
+		annotation.AccessControlAllowOriginListRegex = snippet.AccessControlAllowOriginListRegex
+	case !slices.Equal(annotation.AccessControlAllowOriginList, snippet.AccessControlAllowOriginList) ||
+		!slices.Equal(annotation.AccessControlAllowOriginListRegex, snippet.AccessControlAllowOriginListRegex):
+		conflicts = append(conflicts, "Access-Control-Allow-Origin")
+	}
+
+	switch {
+	case snippet.AccessControlMaxAge == 0:
+	case annotation.AccessControlMaxAge == 0:
+		annotation.AccessControlMaxAge = snippet.AccessControlMaxAge
+	case annotation.AccessControlMaxAge != snippet.AccessControlMaxAge:
+		conflicts = append(conflicts, "Access-Control-Max-Age")
+	}
+
+	if snippetSetsCreds {
+		if _, ok := ctx.Annotations[string(models.CorsAllowCredentials)]; !ok {
+			annotation.AccessControlAllowCredentials = snippet.AccessControlAllowCredentials
+		} else if annotation.AccessControlAllowCredentials != snippet.AccessControlAllowCredentials {
+			conflicts = append(conflicts, "Access-Control-Allow-Credentials")
+		}
+	}
+
+	msg := "configuration-snippet CORS headers were merged into the CORS middleware of the cors-* annotations"
+	if len(conflicts) > 0 {
+		msg += "; the annotations took precedence for " + strings.Join(conflicts, ", ")
+	}
+
+	ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+
+	return true
+}
@@ -0,0 +1,373 @@
+package middleware_test
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestConfigurationSnippets_unconditionalCORS(t *testing.T) {
+	t.Run("should convert unconditional CORS headers into a CORS middleware", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `add_header Access-Control-Allow-Origin "*" always;
+add_header Access-Control-Allow-Methods "GET, POST, OPTIONS" always;
+add_header X-Served-By "nginx";`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		headers := findMiddleware(t, ctx, "cors").Spec.Headers
+		if expected := []string{"*"}; !reflect.DeepEqual(expected, headers.AccessControlAllowOriginList) {
+			t.Errorf("expected origins %v, got %v", expected, headers.AccessControlAllowOriginList)
+		}
+
+		if expected := []string{"GET", "POST", "OPTIONS"}; !reflect.DeepEqual(expected, headers.AccessControlAllowMethods) {
+			t.Errorf("expected methods %v, got %v", expected, headers.AccessControlAllowMethods)
+		}
+
+		if _, ok := headers.CustomResponseHeaders["Access-Control-Allow-Origin"]; ok {
+			t.Errorf("expected no raw Access-Control-Allow-Origin response header")
+		}
+
+		snippet := findMiddleware(t, ctx, "configuration-snippet").Spec.Headers
+		if snippet.CustomResponseHeaders["X-Served-By"] != "nginx" {
+			t.Errorf("expected remaining headers to be kept, got %v", snippet.CustomResponseHeaders)
+		}
+
+		if _, ok := snippet.CustomResponseHeaders["Access-Control-Allow-Origin"]; ok {
+			t.Errorf("expected CORS headers to be removed from the generic headers middleware")
+		}
+	})
+}
+
+func TestConfigurationSnippets_moreSetHeadersCORS(t *testing.T) {
+	t.Run("should parse conditional CORS headers set with more_set_headers", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `if ($http_origin ~* (https://example\.com)) {
+more_set_headers "Access-Control-Allow-Origin: $http_origin";
+more_set_headers "Access-Control-Allow-Methods: GET, POST, OPTIONS";
+more_set_headers "Access-Control-Allow-Headers: Content-Type, Authorization";
+more_set_headers "Access-Control-Allow-Credentials: true";
+more_set_headers "Access-Control-Max-Age: 600";
+}`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		headers := findMiddleware(t, ctx, "cors").Spec.Headers
+
+		if expected := []string{"GET", "POST", "OPTIONS"}; !reflect.DeepEqual(expected, headers.AccessControlAllowMethods) {
+			t.Errorf("expected methods %v, got %v", expected, headers.AccessControlAllowMethods)
+		}
+
+		if expected := []string{"Content-Type", "Authorization"}; !reflect.DeepEqual(expected, headers.AccessControlAllowHeaders) {
+			t.Errorf("expected headers %v, got %v", expected, headers.AccessControlAllowHeaders)
+		}
+
+		if !headers.AccessControlAllowCredentials || headers.AccessControlMaxAge != 600 {
+			t.Errorf("expected credentials and max age to be set, got %+v", headers)
+		}
+
+		if expected := []string{"https://example.com"}; !reflect.DeepEqual(expected, headers.AccessControlAllowOriginList) {
+			t.Errorf("expected origins %v, got %v", expected, headers.AccessControlAllowOriginList)
+		}
+	})
+}
+
+func TestConfigurationSnippets_caseSensitiveOrigin(t *testing.T) {
+	t.Run("should capture a case-sensitive origin match", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `if ($http_origin ~ (https://example\.com)) {
+add_header Access-Control-Allow-Origin "$http_origin";
+add_header Access-Control-Allow-Methods "GET, OPTIONS";
+add_header Access-Control-Allow-Headers "Content-Type";
+}`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		headers := findMiddleware(t, ctx, "cors").Spec.Headers
+		if expected := []string{"https://example.com"}; !reflect.DeepEqual(expected, headers.AccessControlAllowOriginList) {
+			t.Errorf("expected origins %v, got %v", expected, headers.AccessControlAllowOriginList)
+		}
+
+		if !hasWarning(ctx, "matches $http_origin case-sensitively") {
+			t.Errorf("expected case-sensitivity note, got %v", ctx.Result.Warnings)
+		}
+	})
+}
+
+func TestConfigurationSnippets_originAlternation(t *testing.T) {
+	t.Run("should preserve a multi-origin alternation regex", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `if ($http_origin ~* (^https://(www\.)?a\.example\.com$|^https://b\.example\.com$)) {
+add_header Access-Control-Allow-Origin "$http_origin";
+add_header Access-Control-Allow-Methods "GET, OPTIONS";
+add_header Access-Control-Allow-Headers "Content-Type";
+}`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		headers := findMiddleware(t, ctx, "cors").Spec.Headers
+
+		expected := []string{`^https://(www\.)?a\.example\.com$|^https://b\.example\.com$`}
+		if !reflect.DeepEqual(expected, headers.AccessControlAllowOriginListRegex) {
+			t.Fatalf("expected origin regex %v, got %v", expected, headers.AccessControlAllowOriginListRegex)
+		}
+
+		originRegex := regexp.MustCompile(headers.AccessControlAllowOriginListRegex[0])
+		for _, origin := range []string{"https://a.example.com", "https://www.a.example.com", "https://b.example.com"} {
+			if !originRegex.MatchString(origin) {
+				t.Errorf("expected %q to match the origin regex", origin)
+			}
+		}
+
+		if originRegex.MatchString("https://aXexample.com") {
+			t.Errorf("expected escaped dots to be preserved")
+		}
+	})
+}
+
+func TestConfigurationSnippets_invalidOriginRegex(t *testing.T) {
+	t.Run("should skip an origin regex which does not compile", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `if ($http_origin ~* (https://[a-z\.com)) {
+add_header Access-Control-Allow-Origin "$http_origin";
+add_header Access-Control-Allow-Methods "GET, OPTIONS";
+add_header Access-Control-Allow-Headers "Content-Type";
+}`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		headers := findMiddleware(t, ctx, "cors").Spec.Headers
+		if len(headers.AccessControlAllowOriginListRegex) != 0 {
+			t.Errorf("expected no origin regex, got %v", headers.AccessControlAllowOriginListRegex)
+		}
+
+		if !hasWarning(ctx, `origin regex "https://[a-z\\.com" from the CORS snippet is not a valid Go regex`) {
+			t.Errorf("expected invalid origin regex warning, got %v", ctx.Result.Warnings)
+		}
+
+		if !hasWarning(ctx, "add an anchored pattern") {
+			t.Errorf("expected anchor guidance, got %v", ctx.Result.Warnings)
+		}
+	})
+}
+
+func TestConfigurationSnippets_corsFlag(t *testing.T) {
+	t.Run("should convert the $cors flag idiom into a CORS middleware", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `set $cors "";
+if ($http_origin ~* (https://example\.com)) {
+set $cors "true";
+}
+if ($cors = "true") {
+add_header Access-Control-Allow-Origin "$http_origin" always;
+add_header Access-Control-Allow-Methods "GET, POST, OPTIONS" always;
+add_header Access-Control-Allow-Headers "Content-Type, Authorization" always;
+add_header Access-Control-Allow-Credentials "true" always;
+}`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.Middlewares) != 1 {
+			t.Fatalf("expected only the CORS middleware, got %d middlewares", len(ctx.Result.Middlewares))
+		}
+
+		headers := findMiddleware(t, ctx, "cors").Spec.Headers
+		if expected := []string{"https://example.com"}; !reflect.DeepEqual(expected, headers.AccessControlAllowOriginList) {
+			t.Errorf("expected origins %v, got %v", expected, headers.AccessControlAllowOriginList)
+		}
+
+		if expected := []string{"GET", "POST", "OPTIONS"}; !reflect.DeepEqual(expected, headers.AccessControlAllowMethods) {
+			t.Errorf("expected methods %v, got %v", expected, headers.AccessControlAllowMethods)
+		}
+
+		if !headers.AccessControlAllowCredentials {
+			t.Errorf("expected credentials to be allowed")
+		}
+	})
+}
+
+func TestConfigurationSnippets_preflightCORS(t *testing.T) {
+	t.Run("should fold the OPTIONS preflight block into the CORS middleware", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `if ($http_origin ~* (https://example\.com)) {
+add_header Access-Control-Allow-Origin "$http_origin" always;
+add_header Access-Control-Allow-Methods "GET, POST, OPTIONS" always;
+add_header Access-Control-Allow-Headers "Content-Type, Authorization" always;
+}
+if ($request_method = 'OPTIONS') {
+add_header Access-Control-Allow-Origin "$http_origin";
+add_header Access-Control-Allow-Methods "GET, POST, OPTIONS";
+add_header Access-Control-Max-Age 1728000;
+add_header Content-Type 'text/plain charset=UTF-8';
+add_header Content-Length 0;
+return 204;
+}`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.Middlewares) != 1 {
+			t.Fatalf("expected only the CORS middleware, got %d middlewares", len(ctx.Result.Middlewares))
+		}
+
+		headers := findMiddleware(t, ctx, "cors").Spec.Headers
+		if headers.AccessControlMaxAge != 1728000 {
+			t.Errorf("expected max age from the OPTIONS block, got %d", headers.AccessControlMaxAge)
+		}
+
+		if len(ctx.Result.Notes) != 1 || !strings.Contains(ctx.Result.Notes[0], "answers preflight requests automatically") {
+			t.Errorf("expected preflight note, got %v", ctx.Result.Notes)
+		}
+
+		if hasWarning(ctx, "answers preflight requests automatically") {
+			t.Errorf("expected no preflight warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
+
+func TestConfigurationSnippets_literalOrigins(t *testing.T) {
+	t.Run("should emit literal-only alternations as an origin list", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `if ($http_origin ~* (^https://a\.example\.com$|^https://b\.example\.com$)) {
+add_header Access-Control-Allow-Origin "$http_origin";
+add_header Access-Control-Allow-Methods "GET, OPTIONS";
+add_header Access-Control-Allow-Headers "Content-Type";
+}`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		headers := findMiddleware(t, ctx, "cors").Spec.Headers
+
+		expected := []string{"https://a.example.com", "https://b.example.com"}
+		if !reflect.DeepEqual(expected, headers.AccessControlAllowOriginList) {
+			t.Errorf("expected origins %v, got %v", expected, headers.AccessControlAllowOriginList)
+		}
+
+		if len(headers.AccessControlAllowOriginListRegex) != 0 {
+			t.Errorf("expected no origin regex, got %v", headers.AccessControlAllowOriginListRegex)
+		}
+	})
+}
+
+func TestCORS_exposeHeaders(t *testing.T) {
+	t.Run("should map cors-expose-headers on the annotation CORS middleware", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.EnableCORS):        "true",
+			string(models.CorsAllowOrigin):   "https://example.com",
+			string(models.CorsExposeHeaders): "X-Request-Id, X-Total-Count",
+		})
+
+		if err := middleware.CORS(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		headers := findMiddleware(t, ctx, "cors").Spec.Headers
+		if expected := []string{"X-Request-Id", "X-Total-Count"}; !reflect.DeepEqual(expected, headers.AccessControlExposeHeaders) {
+			t.Errorf("expected expose headers %v, got %v", expected, headers.AccessControlExposeHeaders)
+		}
+	})
+
+	t.Run("should map Access-Control-Expose-Headers of a conditional CORS snippet", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `if ($http_origin ~* (https://example\.com)) {
+more_set_headers "Access-Control-Allow-Origin: $http_origin";
+more_set_headers "Access-Control-Allow-Methods: GET, OPTIONS";
+more_set_headers "Access-Control-Expose-Headers: X-Request-Id, X-Total-Count";
+}`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		headers := findMiddleware(t, ctx, "cors").Spec.Headers
+		if expected := []string{"X-Request-Id", "X-Total-Count"}; !reflect.DeepEqual(expected, headers.AccessControlExposeHeaders) {
+			t.Errorf("expected expose headers %v, got %v", expected, headers.AccessControlExposeHeaders)
+		}
+	})
+}
+
+func TestConfigurationSnippets_mergeAnnotationCORS(t *testing.T) {
+	t.Run("should emit a single CORS middleware with annotations winning over the snippet", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.EnableCORS):       "true",
+			string(models.CorsAllowOrigin):  "https://app.example.com",
+			string(models.CorsAllowMethods): "GET, POST",
+			string(models.ConfigurationSnippet): `if ($http_origin ~* (https://example\.com)) {
+more_set_headers "Access-Control-Allow-Origin: $http_origin";
+more_set_headers "Access-Control-Allow-Methods: GET, OPTIONS";
+more_set_headers "Access-Control-Allow-Headers: Content-Type";
+more_set_headers "Access-Control-Max-Age: 600";
+}`,
+		})
+
+		if err := middleware.CORS(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		count := 0
+
+		for _, mw := range ctx.Result.Middlewares {
+			if mw.GetName() == "test-cors" {
+				count++
+			}
+		}
+
+		if count != 1 {
+			t.Fatalf("expected a single CORS middleware, got %d", count)
+		}
+
+		headers := findMiddleware(t, ctx, "cors").Spec.Headers
+
+		if expected := []string{"GET", "POST"}; !reflect.DeepEqual(expected, headers.AccessControlAllowMethods) {
+			t.Errorf("expected the annotation methods %v, got %v", expected, headers.AccessControlAllowMethods)
+		}
+
+		if expected := []string{"https://app.example.com"}; !reflect.DeepEqual(expected, headers.AccessControlAllowOriginList) {
+			t.Errorf("expected the annotation origins %v, got %v", expected, headers.AccessControlAllowOriginList)
+		}
+
+		if expected := []string{"Content-Type"}; !reflect.DeepEqual(expected, headers.AccessControlAllowHeaders) {
+			t.Errorf("expected the snippet headers %v, got %v", expected, headers.AccessControlAllowHeaders)
+		}
+
+		if headers.AccessControlMaxAge != 600 {
+			t.Errorf("expected the snippet max age, got %d", headers.AccessControlMaxAge)
+		}
+
+		if !hasWarning(ctx, "the annotations took precedence for Access-Control-Allow-Methods, Access-Control-Allow-Origin") {
+			t.Errorf("expected a conflict warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
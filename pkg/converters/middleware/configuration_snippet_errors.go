@@ -0,0 +1,225 @@
+package middleware
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	traefik "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+/* ---------------- error_page handling ---------------- */
+
+// errorPage is a parsed `error_page <codes...> [=<code>] <uri>;` directive.
+type errorPage struct {
+	line     string
+	statuses []string
+	override string
+	target   string
+}
+
+// parseErrorPage parses a single error_page directive. The override holds the
+// `=<code>` response status, or "=" alone when the status is taken from the target.
+func parseErrorPage(line string) (errorPage, bool) {
+	args := directiveArgs(line)
+
+	const minErrorPageArgs = 2
+
+	if len(args) < minErrorPageArgs {
+		return errorPage{}, false
+	}
+
+	page := errorPage{line: line, target: args[len(args)-1]}
+
+	for _, arg := range args[:len(args)-1] {
+		if strings.HasPrefix(arg, "=") {
+			page.override = arg
+
+			continue
+		}
+
+		if _, err := strconv.Atoi(arg); err != nil {
+			return errorPage{}, false
+		}
+
+		page.statuses = append(page.statuses, arg)
+	}
+
+	return page, len(page.statuses) > 0
+}
+
+// convertErrorPages turns the snippet error_page directives into Errors
+// middlewares served by the Ingress backend. One middleware is generated per
+// distinct error page target.
+func convertErrorPages(ctx configs.Context, pages []errorPage, warnings *[]string) {
+	service, ok := errorPageService(ctx.Ingress)
+	if !ok {
+		*warnings = append(*warnings,
+			"configuration-snippet error_page directives were not converted: the backend serving the error pages "+
+				"could not be derived; set spec.defaultBackend or create a Traefik Errors middleware manually",
+		)
+
+		return
+	}
+
+	byTarget := make(map[string]*traefik.ErrorPage)
+	targets := make([]string, 0, len(pages))
+
+	for _, page := range pages {
+		if strings.HasPrefix(page.target, "@") || strings.Contains(page.target, "://") {
+			*warnings = append(*warnings, fmt.Sprintf(
+				"%s was not converted: Traefik Errors middleware can only query a path on a backend service, "+
+					"not a named location or an absolute URL", page.line,
+			))
+
+			continue
+		}
+
+		spec, exists := byTarget[page.target]
+		if !exists {
+			spec = &traefik.ErrorPage{Service: service, Query: page.target}
+			byTarget[page.target] = spec
+			targets = append(targets, page.target)
+		}
+
+		spec.Status = append(spec.Status, page.statuses...)
+
+		applyErrorPageOverride(spec, page, warnings)
+	}
+
+	for index, target := range targets {
+		name := "error-page"
+		if len(targets) > 1 {
+			name = fmt.Sprintf("error-page-%d", index+1)
+		}
+
+		ctx.Result.Middlewares = append(ctx.Result.Middlewares, newErrorsMiddleware(ctx, name, byTarget[target]))
+	}
+}
+
+func applyErrorPageOverride(spec *traefik.ErrorPage, page errorPage, warnings *[]string) {
+	if page.override == "" {
+		return
+	}
+
+	code, err := strconv.Atoi(strings.TrimPrefix(page.override, "="))
+	if err != nil {
+		*warnings = append(*warnings, fmt.Sprintf(
+			"%s takes the response status from the error page target; Traefik keeps the original status, "+
+				"review the generated Errors middleware", page.line,
+		))
+
+		return
+	}
+
+	if spec.StatusRewrites == nil {
+		spec.StatusRewrites = make(map[string]int, len(page.statuses))
+	}
+
+	for _, status := range page.statuses {
+		spec.StatusRewrites[status] = code
+	}
+
+	*warnings = append(*warnings, fmt.Sprintf(
+		"%s overrides the response status with %d; mapped to Errors statusRewrites, "+
+			"verify the error page is served with the expected status", page.line, code,
+	))
+}
+
+// errorPageService resolves the service serving the error pages: the Ingress
+// default backend, or the backend shared by all rules.
+func errorPageService(ing *netv1.Ingress) (traefik.Service, bool) {
+	if ing == nil {
+		return traefik.Service{}, false
+	}
+
+	if ing.Spec.DefaultBackend != nil && ing.Spec.DefaultBackend.Service != nil {
+		return serviceFromBackend(ing.Spec.DefaultBackend.Service), true
+	}
+
+	var backend *netv1.IngressServiceBackend
+
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+
+		for _, path := range rule.HTTP.Paths {
+			svc := path.Backend.Service
+			if svc == nil {
+				continue
+			}
+
+			if backend != nil && (backend.Name != svc.Name || backend.Port != svc.Port) {
+				return traefik.Service{}, false
+			}
+
+			backend = svc
+		}
+	}
+
+	if backend == nil {
+		return traefik.Service{}, false
+	}
+
+	return serviceFromBackend(backend), true
+}
+
+func serviceFromBackend(backend *netv1.IngressServiceBackend) traefik.Service {
+	port := intstr.FromInt32(backend.Port.Number)
+	if backend.Port.Name != "" {
+		port = intstr.FromString(backend.Port.Name)
+	}
+
+	return traefik.Service{
+		LoadBalancerSpec: traefik.LoadBalancerSpec{
+			Name: backend.Name,
+			Port: port,
+		},
+	}
+}
+
+func newErrorsMiddleware(
+	ctx configs.Context,
+	name string,
+	spec *traefik.ErrorPage,
+) *traefik.Middleware {
+	return &traefik.Middleware{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: traefik.SchemeGroupVersion.String(),
+			Kind:       "Middleware",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mwName(ctx, name),
+			Namespace: ctx.Namespace,
+		},
+		Spec: traefik.MiddlewareSpec{
+			Errors: spec,
+		},
+	}
+}
+
+// interceptErrorsGuidance explains how `proxy_intercept_errors <mode>;` relates
+// to the Errors middleware generated from the error_page directives of the
+// snippet, which always intercepts the error responses of the backend.
+func interceptErrorsGuidance(ctx configs.Context, mode string, hasErrorPages bool, warnings *[]string) {
+	switch {
+	case mode == "on" && hasErrorPages:
+		ctx.Result.Notes = append(ctx.Result.Notes,
+			"proxy_intercept_errors on is covered by the Errors middleware generated from the error_page directives",
+		)
+	case mode == "on":
+		*warnings = append(*warnings,
+			"proxy_intercept_errors on has no error_page directives in the configuration-snippet to intercept errors for and was ignored",
+		)
+	case mode == "off" && hasErrorPages:
+		*warnings = append(*warnings,
+			"proxy_intercept_errors off only applies error_page to errors generated by NGINX, "+
+				"but the Traefik Errors middleware also replaces error responses of the backend",
+		)
+	}
+}
@@ -0,0 +1,125 @@
+package middleware_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+	netv1 "k8s.io/api/networking/v1"
+)
+
+func withDefaultBackend(ctx configs.Context) configs.Context {
+	ctx.Ingress.Spec.DefaultBackend = &netv1.IngressBackend{
+		Service: &netv1.IngressServiceBackend{
+			Name: "app",
+			Port: netv1.ServiceBackendPort{Number: 8080},
+		},
+	}
+
+	return ctx
+}
+
+func TestConfigurationSnippets_errorPage(t *testing.T) {
+	t.Run("should convert error_page into an Errors middleware", func(t *testing.T) {
+		ctx := withDefaultBackend(newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `error_page 404 /custom_404.html;
+error_page 500 502 503 /custom_404.html;`,
+		}))
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		errors := findMiddleware(t, ctx, "error-page").Spec.Errors
+		if errors == nil {
+			t.Fatalf("expected Errors middleware")
+		}
+
+		if expected := []string{"404", "500", "502", "503"}; !reflect.DeepEqual(expected, errors.Status) {
+			t.Errorf("expected status %v, got %v", expected, errors.Status)
+		}
+
+		if errors.Query != "/custom_404.html" || errors.Service.Name != "app" || errors.Service.Port.IntVal != 8080 {
+			t.Errorf("unexpected Errors middleware: %+v", errors)
+		}
+	})
+
+	t.Run("should map the status override form to statusRewrites with a warning", func(t *testing.T) {
+		ctx := withDefaultBackend(newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `error_page 404 =200 /empty.gif;`,
+		}))
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		errors := findMiddleware(t, ctx, "error-page").Spec.Errors
+		if expected := map[string]int{"404": 200}; !reflect.DeepEqual(expected, errors.StatusRewrites) {
+			t.Errorf("expected status rewrites %v, got %v", expected, errors.StatusRewrites)
+		}
+
+		if !hasWarning(ctx, "overrides the response status with 200") {
+			t.Errorf("expected status override warning, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should warn when the error page backend cannot be derived", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `error_page 404 /custom_404.html;`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.Middlewares) != 0 {
+			t.Errorf("expected no middleware, got %d", len(ctx.Result.Middlewares))
+		}
+
+		if !hasWarning(ctx, "could not be derived") {
+			t.Errorf("expected backend warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
+
+func TestConfigurationSnippets_proxyInterceptErrors(t *testing.T) {
+	t.Run("should note that the Errors middleware covers proxy_intercept_errors", func(t *testing.T) {
+		ctx := withDefaultBackend(newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `proxy_intercept_errors on;
+error_page 500 502 /50x.html;`,
+		}))
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if findMiddleware(t, ctx, "error-page").Spec.Errors == nil {
+			t.Fatalf("expected Errors middleware")
+		}
+
+		if len(ctx.Result.Notes) != 1 || !strings.Contains(ctx.Result.Notes[0], "covered by the Errors middleware") {
+			t.Errorf("expected a note about the Errors middleware, got %v", ctx.Result.Notes)
+		}
+
+		if hasWarning(ctx, "unsupported directive") {
+			t.Errorf("expected no generic drop warning, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should warn when there are no error pages to intercept", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `proxy_intercept_errors on;`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !hasWarning(ctx, "proxy_intercept_errors on has no error_page directives") {
+			t.Errorf("expected missing error_page warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+)
+
+/* ---------------- map / geo handling ---------------- */
+
+var mapBlockRe = regexp.MustCompile(`(?i)^(map|geo)\s+(?:(\$\S+)\s+)?(\$[^\s{]+)\s*\{\s*(.*)$`)
+
+// convertMapBlocks recognises `map $source $target { ... }` and `geo` blocks.
+// They drive conditional logic through variables, which Traefik has no notion
+// of, so each block is reported once as a whole instead of line by line.
+// It returns the snippet lines that were not consumed.
+func convertMapBlocks(ctx configs.Context, lines []string) []string {
+	rest := make([]string, 0, len(lines))
+
+	for index := 0; index < len(lines); index++ {
+		match := mapBlockRe.FindStringSubmatch(lines[index])
+		if match == nil {
+			rest = append(rest, lines[index])
+
+			continue
+		}
+
+		body, end, ok := collectBlock(lines, index, match[4])
+		if !ok {
+			rest = append(rest, lines[index])
+
+			continue
+		}
+
+		entries := 0
+
+		for _, line := range body {
+			for _, entry := range strings.Split(line, ";") {
+				if strings.TrimSpace(entry) != "" {
+					entries++
+				}
+			}
+		}
+
+		kind, source, target := strings.ToLower(match[1]), match[2], match[3]
+		if source == "" {
+			// geo defaults to the client address
+			source = "$remote_addr"
+		}
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, fmt.Sprintf(
+			"%s block mapping %s to %s (%d entries) was not converted; Traefik has no variables, "+
+				"the mapping logic has to be reimplemented, e.g. with dedicated routers or middlewares per value",
+			kind, source, target, entries,
+		))
+
+		index = end
+	}
+
+	return rest
+}
@@ -0,0 +1,37 @@
+package middleware_test
+
+import (
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestConfigurationSnippets_mapBlock(t *testing.T) {
+	t.Run("should report a map block once with its variables", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `map $http_x_tenant $backend_pool {
+  default "pool-a";
+  "beta"  "pool-b";
+}
+add_header X-Foo bar;`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !hasWarning(ctx, "map block mapping $http_x_tenant to $backend_pool (2 entries) was not converted") {
+			t.Errorf("expected map block warning, got %v", ctx.Result.Warnings)
+		}
+
+		if len(ctx.Result.Warnings) != 1 {
+			t.Errorf("expected a single warning for the block, got %v", ctx.Result.Warnings)
+		}
+
+		headers := findMiddleware(t, ctx, "configuration-snippet").Spec.Headers
+		if headers.CustomResponseHeaders["X-Foo"] != "bar" {
+			t.Errorf("expected remaining directives to be converted")
+		}
+	})
+}
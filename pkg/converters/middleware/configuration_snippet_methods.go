@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+)
+
+/* ---------------- limit_except handling ---------------- */
+
+var limitExceptRe = regexp.MustCompile(`(?i)^limit_except\s+([^{]+?)\s*\{\s*(.*)$`)
+
+// convertLimitExcept recognises `limit_except <methods> { ... }` blocks. Traefik
+// restricts methods with router matchers rather than middlewares, so the block
+// is turned into guidance listing the Method() matchers to add to the route.
+// It returns the snippet lines that were not consumed.
+func convertLimitExcept(ctx configs.Context, lines []string) []string {
+	rest := make([]string, 0, len(lines))
+
+	for index := 0; index < len(lines); index++ {
+		match := limitExceptRe.FindStringSubmatch(lines[index])
+		if match == nil {
+			rest = append(rest, lines[index])
+
+			continue
+		}
+
+		_, end, ok := collectBlock(lines, index, match[2])
+		if !ok {
+			rest = append(rest, lines[index])
+
+			continue
+		}
+
+		methods := strings.Fields(strings.ToUpper(match[1]))
+
+		// NGINX implicitly allows HEAD whenever GET is allowed.
+		if slices.Contains(methods, "GET") && !slices.Contains(methods, "HEAD") {
+			methods = append(methods, "HEAD")
+		}
+
+		matchers := make([]string, 0, len(methods))
+		for _, method := range methods {
+			matchers = append(matchers, fmt.Sprintf("Method(`%s`)", method))
+		}
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, fmt.Sprintf(
+			"configuration-snippet 'limit_except' restricts requests to methods %s; Traefik cannot restrict methods "+
+				"via middleware, add '(%s)' to the IngressRoute match instead",
+			strings.Join(methods, ", "), strings.Join(matchers, " || "),
+		))
+
+		index = end
+	}
+
+	return rest
+}
@@ -0,0 +1,36 @@
+package middleware_test
+
+import (
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestConfigurationSnippets_limitExcept(t *testing.T) {
+	t.Run("should emit method restriction guidance for limit_except", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `limit_except GET POST {
+  deny all;
+}`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !hasWarning(ctx, "(Method(`GET`) || Method(`POST`) || Method(`HEAD`))") {
+			t.Errorf("expected Method() matcher guidance, got %v", ctx.Result.Warnings)
+		}
+
+		if hasWarning(ctx, "unsupported directive") {
+			t.Errorf("expected the block to be consumed, got %v", ctx.Result.Warnings)
+		}
+
+		entries := ctx.Result.IngressReport.Entries
+		if len(entries) != 1 || entries[0].Status != configs.AnnotationWarned {
+			t.Errorf("expected the guidance only snippet to be reported as a warning, got %v", entries)
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+/* ---------------- proxy_set_header handling ---------------- */
+
+const proxyPassRequestHeadersOffWarning = "proxy_pass_request_headers off stops forwarding client headers, " +
+	"but Traefik always forwards request headers and has no blanket switch to disable it; " +
+	"list the headers to strip explicitly in a Headers middleware (customRequestHeaders set to \"\")"
+
+// forwardedHeaderVariables lists the forwarding headers Traefik sets on every
+// proxied request, with the NGINX variables that yield the same value.
+var forwardedHeaderVariables = map[string][]string{
+	"x-forwarded-for":   {"$proxy_add_x_forwarded_for", "$remote_addr"},
+	"x-forwarded-host":  {"$host", "$http_host"},
+	"x-forwarded-port":  {"$server_port"},
+	"x-forwarded-proto": {"$scheme"},
+	"x-real-ip":         {"$remote_addr"},
+}
+
+// isRedundantForwardedHeader reports whether a `proxy_set_header <key> <val>`
+// directive only reproduces a forwarding header Traefik already sets. A literal
+// value is an intentional override and is kept.
+func isRedundantForwardedHeader(key, val string) bool {
+	variables, ok := forwardedHeaderVariables[strings.ToLower(key)]
+	if !ok {
+		return false
+	}
+
+	for _, variable := range variables {
+		if strings.EqualFold(strings.Trim(val, `"'`), variable) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasWebsocketUpgradeHeaders reports whether the snippet sets both the Upgrade
+// and Connection request headers the way websocket proxying is usually set up
+// in NGINX. Traefik forwards upgrade requests natively, so the pair is redundant.
+func hasWebsocketUpgradeHeaders(lines []string) bool {
+	var hasUpgrade, hasConnection bool
+
+	for _, line := range lines {
+		if directive(strings.ToLower(line)) != "proxy_set_header" {
+			continue
+		}
+
+		key, val, _ := parseProxySetHeader(line)
+
+		switch strings.ToLower(key) {
+		case "upgrade":
+			hasUpgrade = isWebsocketUpgradeHeader(key, val)
+		case "connection":
+			hasConnection = isWebsocketUpgradeHeader(key, val)
+		}
+	}
+
+	return hasUpgrade && hasConnection
+}
+
+func isWebsocketUpgradeHeader(key, val string) bool {
+	val = strings.ToLower(strings.Trim(val, `"'`))
+
+	switch strings.ToLower(key) {
+	case "upgrade":
+		return val == "$http_upgrade" || val == "websocket"
+	case "connection":
+		return strings.Contains(val, "upgrade")
+	default:
+		return false
+	}
+}
+
+// keepHostHeader decides whether a `proxy_set_header Host <val>` directive should
+// be turned into a custom request header. The upstream-vhost annotation always
+// wins over the snippet so that a single Host header is generated, and the
+// `$host`/`$http_host` forms are dropped since Traefik forwards the client Host
+// header by default.
+func keepHostHeader(ctx configs.Context, val string, warnings *[]string) bool {
+	if vhost := strings.TrimSpace(ctx.Annotations[string(models.UpstreamVhost)]); vhost != "" {
+		*warnings = append(*warnings, fmt.Sprintf(
+			"configuration-snippet sets the Host header to %q but upstream-vhost sets it to %q; "+
+				"the upstream-vhost annotation takes precedence and the snippet value was dropped",
+			val, vhost,
+		))
+
+		return false
+	}
+
+	switch strings.ToLower(strings.Trim(val, `"'`)) {
+	case "$host", "$http_host":
+		*warnings = append(*warnings,
+			"proxy_set_header Host "+val+" is redundant; Traefik forwards the client Host header by default",
+		)
+
+		return false
+	}
+
+	return true
+}
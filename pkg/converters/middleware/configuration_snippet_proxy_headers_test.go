@@ -0,0 +1,231 @@
+package middleware_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestConfigurationSnippets_websocketHeaders(t *testing.T) {
+	t.Run("should not set websocket upgrade headers and add a note instead", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `proxy_set_header Upgrade $http_upgrade;
+proxy_set_header Connection "upgrade";`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.Middlewares) != 0 {
+			t.Errorf("expected no middlewares, got %d", len(ctx.Result.Middlewares))
+		}
+
+		if !hasWarning(ctx, "Traefik handles websocket upgrades out of the box") {
+			t.Errorf("expected a websocket note, got %v", ctx.Result.Warnings)
+		}
+
+		if hasWarning(ctx, "NGINX variables") {
+			t.Errorf("expected no variable warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
+
+func TestConfigurationSnippets_hostHeaderConflict(t *testing.T) {
+	t.Run("should keep the upstream-vhost Host header and warn about the snippet one", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.UpstreamVhost):        "backend.internal",
+			string(models.ConfigurationSnippet): `proxy_set_header Host example.com;`,
+		})
+
+		middleware.UpstreamVHost(ctx)
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		hosts := make([]string, 0)
+
+		for _, mw := range ctx.Result.Middlewares {
+			if mw.Spec.Headers == nil {
+				continue
+			}
+
+			if host, ok := mw.Spec.Headers.CustomRequestHeaders["Host"]; ok {
+				hosts = append(hosts, host)
+			}
+		}
+
+		if len(hosts) != 1 || hosts[0] != "backend.internal" {
+			t.Errorf("expected a single Host header set to backend.internal, got %v", hosts)
+		}
+
+		if !hasWarning(ctx, "upstream-vhost annotation takes precedence") {
+			t.Errorf("expected a conflict warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
+
+func TestConfigurationSnippets_proxyPassRequestHeaders(t *testing.T) {
+	t.Run("should warn that request headers cannot be stripped wholesale", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `proxy_pass_request_headers off;`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !hasWarning(ctx, "no blanket switch to disable it") {
+			t.Errorf("expected proxy_pass_request_headers warning, got %v", ctx.Result.Warnings)
+		}
+
+		if hasWarning(ctx, "unsupported directive") {
+			t.Errorf("expected no generic drop warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
+
+func TestConfigurationSnippets_addTrailer(t *testing.T) {
+	t.Run("should warn that trailers cannot be set by Traefik", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `add_trailer X-Checksum $upstream_http_checksum;`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !hasWarning(ctx, "add_trailer sets HTTP trailers which cannot be set via Traefik middleware") {
+			t.Errorf("expected add_trailer warning, got %v", ctx.Result.Warnings)
+		}
+
+		if hasWarning(ctx, "unsupported directive") {
+			t.Errorf("expected no generic drop warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
+
+func TestConfigurationSnippets_proxyIgnoreHeaders(t *testing.T) {
+	t.Run("should warn that proxy_ignore_headers has no effect and list the headers", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `proxy_ignore_headers X-Accel-Redirect Cache-Control;`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !hasWarning(ctx, "proxy_ignore_headers X-Accel-Redirect Cache-Control has no effect in Traefik") {
+			t.Errorf("expected proxy_ignore_headers warning, got %v", ctx.Result.Warnings)
+		}
+
+		if hasWarning(ctx, "unsupported directive") {
+			t.Errorf("expected no generic drop warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
+
+func TestConfigurationSnippets_clearRequestHeader(t *testing.T) {
+	t.Run("should treat an empty proxy_set_header value as removal", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `proxy_set_header X-Forwarded-User "";`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		headers := findMiddleware(t, ctx, "configuration-snippet").Spec.Headers
+		if val, ok := headers.CustomRequestHeaders["X-Forwarded-User"]; !ok || val != "" {
+			t.Errorf("expected X-Forwarded-User to be set to an empty value, got %v", headers.CustomRequestHeaders)
+		}
+
+		if !hasWarning(ctx, "proxy_set_header clears request header X-Forwarded-User") {
+			t.Errorf("expected removal note, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should warn on a proxy_set_header without value", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `proxy_set_header X-Forwarded-User ;`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.Middlewares) != 0 {
+			t.Errorf("expected no middleware, got %d", len(ctx.Result.Middlewares))
+		}
+
+		if !hasWarning(ctx, "failed to parse proxy_set_header directive") {
+			t.Errorf("expected parse warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
+
+func TestConfigurationSnippets_forwardedHost(t *testing.T) {
+	t.Run("should note that X-Forwarded-Host $host is redundant", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `proxy_set_header X-Forwarded-Host $host;`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.Middlewares) != 0 {
+			t.Errorf("expected no middlewares, got %d", len(ctx.Result.Middlewares))
+		}
+
+		if len(ctx.Result.Notes) != 1 || !strings.Contains(ctx.Result.Notes[0], "Traefik sets the X-Forwarded-Host header automatically") {
+			t.Errorf("expected redundancy note, got %v", ctx.Result.Notes)
+		}
+
+		if hasWarning(ctx, "NGINX variables") {
+			t.Errorf("expected no variable warning, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should keep a literal X-Forwarded-Host as an override", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `proxy_set_header X-Forwarded-Host app.example.com;`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		headers := findMiddleware(t, ctx, "configuration-snippet").Spec.Headers
+		if got := headers.CustomRequestHeaders["X-Forwarded-Host"]; got != "app.example.com" {
+			t.Errorf("expected X-Forwarded-Host app.example.com, got %q", got)
+		}
+
+		if len(ctx.Result.Notes) != 0 {
+			t.Errorf("expected no notes, got %v", ctx.Result.Notes)
+		}
+	})
+}
+
+func TestConfigurationSnippets_proxyPassHeader(t *testing.T) {
+	t.Run("should note that proxy_pass_header is a no-op", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `proxy_pass_header X-Accel-Redirect;`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.Notes) != 1 || !strings.Contains(ctx.Result.Notes[0], "proxy_pass_header X-Accel-Redirect is a no-op") {
+			t.Errorf("expected a no-op note, got %v", ctx.Result.Notes)
+		}
+
+		if len(ctx.Result.Warnings) != 0 {
+			t.Errorf("expected no warnings, got %v", ctx.Result.Warnings)
+		}
+	})
+}
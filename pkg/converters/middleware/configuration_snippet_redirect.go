@@ -0,0 +1,230 @@
+package middleware
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+/* ---------------- Scheme redirect handling ---------------- */
+
+var (
+	schemeIfRe    = regexp.MustCompile(`(?i)^if\s*\(\s*\$scheme\s*=\s*["']?http["']?\s*\)\s*\{\s*(.*)$`)
+	httpsReturnRe = regexp.MustCompile(`(?i)^return\s+(30[1278])\s+["']?https://\S+?["']?\s*;?$`)
+	hostIfRe      = regexp.MustCompile(`(?i)^if\s*\(\s*\$host\s*=\s*["']?([^"'\s)]+)["']?\s*\)\s*\{\s*(.*)$`)
+	hostReturnRe  = regexp.MustCompile(`(?i)^return\s+(30[1278])\s+["']?(https?://[^$"'\s]+)(\$request_uri)?["']?\s*;?$`)
+	rewriteAllRe  = regexp.MustCompile(`(?i)^rewrite\s+["']?\^(/?\(\.\*\)\$?|\.\*\$?)?["']?\s+` +
+		`["']?(https?://[^"'\s]+)["']?\s+(permanent|redirect)\s*;?$`)
+)
+
+// convertSchemeRedirect recognises the `if ($scheme = http) { return 301 https://...; }`
+// idiom, which is a plain HTTP to HTTPS redirect, and converts it to the same
+// RedirectScheme middleware that the ssl-redirect annotation produces.
+// It returns the snippet lines that were not consumed.
+func convertSchemeRedirect(ctx configs.Context, lines []string) []string {
+	rest := make([]string, 0, len(lines))
+
+	for index := 0; index < len(lines); index++ {
+		match := schemeIfRe.FindStringSubmatch(lines[index])
+		if match == nil {
+			rest = append(rest, lines[index])
+
+			continue
+		}
+
+		body, end, ok := collectBlock(lines, index, match[1])
+		if !ok || len(body) != 1 {
+			rest = append(rest, lines[index])
+
+			continue
+		}
+
+		ret := httpsReturnRe.FindStringSubmatch(body[0])
+		if ret == nil {
+			rest = append(rest, lines[index])
+
+			continue
+		}
+
+		emitSchemeRedirect(ctx, ret[1] == "301" || ret[1] == "308", "'if ($scheme = http)' redirect")
+
+		index = end
+	}
+
+	return rest
+}
+
+// convertHostRedirect recognises the `if ($host = old.example.com) { return 301
+// https://new.example.com$request_uri; }` idiom and converts it to a RedirectRegex
+// middleware matching the old host. The request URI is kept when the return
+// target ends with $request_uri.
+// It returns the snippet lines that were not consumed.
+func convertHostRedirect(ctx configs.Context, lines []string) []string {
+	rest := make([]string, 0, len(lines))
+	count := 0
+
+	for index := 0; index < len(lines); index++ {
+		match := hostIfRe.FindStringSubmatch(lines[index])
+		if match == nil {
+			rest = append(rest, lines[index])
+
+			continue
+		}
+
+		body, end, ok := collectBlock(lines, index, match[2])
+		if !ok || len(body) != 1 {
+			rest = append(rest, lines[index])
+
+			continue
+		}
+
+		ret := hostReturnRe.FindStringSubmatch(body[0])
+		if ret == nil {
+			rest = append(rest, lines[index])
+
+			continue
+		}
+
+		redirect := &dynamic.RedirectRegex{
+			Regex:       `^https?://` + regexp.QuoteMeta(match[1]) + `(?::\d+)?(.*)$`,
+			Replacement: ret[2],
+			Permanent:   ret[1] == "301" || ret[1] == "308",
+		}
+
+		if ret[3] != "" {
+			redirect.Replacement += "${1}"
+		}
+
+		count++
+
+		name := "host-redirect"
+		if count > 1 {
+			name = fmt.Sprintf("host-redirect-%d", count)
+		}
+
+		ctx.Result.Middlewares = append(ctx.Result.Middlewares, newRedirectRegexMiddleware(ctx, name, redirect))
+
+		index = end
+	}
+
+	return rest
+}
+
+// convertRewriteRedirect recognises an unconditional `rewrite ^ https://... permanent;`
+// which redirects every request to an absolute URL. A rewrite only switching to
+// https becomes a RedirectScheme middleware, any other target a RedirectRegex.
+// Rewrites of the path are not consumed.
+// It returns the snippet lines that were not consumed.
+func convertRewriteRedirect(ctx configs.Context, lines []string) []string {
+	rest := make([]string, 0, len(lines))
+
+	for _, line := range lines {
+		match := rewriteAllRe.FindStringSubmatch(line)
+		if match == nil {
+			rest = append(rest, line)
+
+			continue
+		}
+
+		pattern, target, permanent := match[1], match[2], strings.EqualFold(match[3], "permanent")
+
+		// $1 is empty in NGINX when the pattern has no capture group, `^/(.*)` captures
+		// the URI without its leading slash and `^(.*)` the whole URI.
+		captures, slashless := strings.Contains(pattern, "("), strings.HasPrefix(pattern, "/")
+		if !captures {
+			target = strings.ReplaceAll(target, "$1", "")
+		}
+
+		uriRegex := `^https?://[^/]+(.*)$`
+		if slashless && strings.HasSuffix(target, "$1") {
+			uriRegex = `^https?://[^/]+/(.*)$`
+		}
+
+		switch {
+		case strings.EqualFold(target, "https://$host$request_uri"),
+			strings.EqualFold(target, "https://$host$1") && !slashless:
+			emitSchemeRedirect(ctx, permanent, "'rewrite ^ https://$host' redirect")
+		case !strings.Contains(target, "$"):
+			ctx.Result.Middlewares = append(ctx.Result.Middlewares,
+				newRedirectRegexMiddleware(ctx, "rewrite-redirect", &dynamic.RedirectRegex{
+					Regex:       "^.*$",
+					Replacement: target,
+					Permanent:   permanent,
+				}),
+			)
+		case redirectKeepsURI(target):
+			ctx.Result.Middlewares = append(ctx.Result.Middlewares,
+				newRedirectRegexMiddleware(ctx, "rewrite-redirect", &dynamic.RedirectRegex{
+					Regex:       uriRegex,
+					Replacement: strings.TrimSuffix(strings.TrimSuffix(target, "$request_uri"), "$1") + "${1}",
+					Permanent:   permanent,
+				}),
+			)
+		default:
+			rest = append(rest, line)
+		}
+	}
+
+	return rest
+}
+
+// redirectKeepsURI reports whether the rewrite target is a fixed URL followed by
+// the request URI, either as $request_uri or as the $1 capture of `^(.*)$` or `^/(.*)$`.
+func redirectKeepsURI(target string) bool {
+	for _, suffix := range []string{"$request_uri", "$1"} {
+		if base, found := strings.CutSuffix(target, suffix); found && !strings.Contains(base, "$") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// collectBlock gathers the statements of the block opened at lines[start], where
+// first is whatever followed the opening brace on that line. It returns the
+// block statements, the index of the line closing the block and whether a
+// closing brace was found.
+func collectBlock(lines []string, start int, first string) ([]string, int, bool) {
+	body := make([]string, 0)
+	line := strings.TrimSpace(first)
+	index := start
+
+	for {
+		closed := strings.HasSuffix(line, "}")
+		line = strings.TrimSpace(strings.TrimSuffix(line, "}"))
+
+		if line != "" {
+			body = append(body, line)
+		}
+
+		if closed {
+			return body, index, true
+		}
+
+		index++
+		if index >= len(lines) {
+			return nil, 0, false
+		}
+
+		line = strings.TrimSpace(lines[index])
+	}
+}
+
+func emitSchemeRedirect(ctx configs.Context, permanent bool, source string) {
+	if hasMiddleware(ctx, "https-redirect") {
+		ctx.Result.Warnings = append(ctx.Result.Warnings,
+			"configuration-snippet HTTP to HTTPS redirect duplicates the ssl-redirect annotation; the existing redirect middleware was kept",
+		)
+
+		return
+	}
+
+	ctx.Result.Middlewares = append(ctx.Result.Middlewares, newHTTPSRedirectMiddleware(ctx, permanent))
+
+	ctx.Result.Warnings = append(ctx.Result.Warnings,
+		"configuration-snippet "+source+" was converted to a Traefik RedirectScheme middleware",
+	)
+}
@@ -0,0 +1,194 @@
+package middleware_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestConfigurationSnippets_schemeRedirect(t *testing.T) {
+	snippet := `if ($scheme = http) {
+  return 301 https://$host$request_uri;
+}
+add_header X-Foo bar;`
+
+	t.Run("should convert the scheme redirect snippet to a RedirectScheme middleware", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): snippet,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		redirect := findMiddleware(t, ctx, "https-redirect").Spec.RedirectScheme
+		if redirect == nil || redirect.Scheme != "https" || !redirect.Permanent {
+			t.Fatalf("expected a permanent https RedirectScheme, got %+v", redirect)
+		}
+
+		if hasWarning(ctx, "unsupported directive") {
+			t.Errorf("expected no per-directive warnings, got %v", ctx.Result.Warnings)
+		}
+
+		headers := findMiddleware(t, ctx, "configuration-snippet").Spec.Headers
+		if headers.CustomResponseHeaders["X-Foo"] != "bar" {
+			t.Errorf("expected remaining directives to be converted")
+		}
+	})
+
+	t.Run("should not duplicate the redirect set by the ssl-redirect annotation", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.SSLRedirect):          "true",
+			string(models.ConfigurationSnippet): snippet,
+		})
+
+		middleware.SSLRedirect(ctx)
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		count := 0
+
+		for _, mw := range ctx.Result.Middlewares {
+			if mw.Spec.RedirectScheme != nil {
+				count++
+			}
+		}
+
+		if count != 1 {
+			t.Errorf("expected exactly one RedirectScheme middleware, got %d", count)
+		}
+
+		if !hasWarning(ctx, "duplicates the ssl-redirect annotation") {
+			t.Errorf("expected a dedupe warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
+
+func TestConfigurationSnippets_hostRedirect(t *testing.T) {
+	t.Run("should convert the host redirect snippet to a RedirectRegex middleware", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `if ($host = 'old.example.com') {
+  return 301 https://new.example.com$request_uri;
+}`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		redirect := findMiddleware(t, ctx, "host-redirect").Spec.RedirectRegex
+		if redirect == nil {
+			t.Fatal("expected a RedirectRegex middleware")
+		}
+
+		if redirect.Regex != `^https?://old\.example\.com(?::\d+)?(.*)$` {
+			t.Errorf("unexpected regex %q", redirect.Regex)
+		}
+
+		if redirect.Replacement != "https://new.example.com${1}" || !redirect.Permanent {
+			t.Errorf("expected a permanent redirect preserving the URI, got %+v", redirect)
+		}
+
+		if len(ctx.Result.Middlewares) != 1 {
+			t.Errorf("expected only the redirect middleware, got %d middlewares", len(ctx.Result.Middlewares))
+		}
+
+		if hasWarning(ctx, "unsupported directive") {
+			t.Errorf("expected no per-directive warnings, got %v", ctx.Result.Warnings)
+		}
+	})
+}
+
+func TestConfigurationSnippets_rewriteRedirect(t *testing.T) {
+	t.Run("should convert an unconditional rewrite to an absolute URL into a RedirectRegex", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `rewrite ^ https://example.com permanent;`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		redirect := findMiddleware(t, ctx, "rewrite-redirect").Spec.RedirectRegex
+		if redirect == nil || redirect.Regex != "^.*$" || redirect.Replacement != "https://example.com" || !redirect.Permanent {
+			t.Fatalf("expected a permanent RedirectRegex to https://example.com, got %+v", redirect)
+		}
+
+		if hasWarning(ctx, "unsupported directive") {
+			t.Errorf("expected no per-directive warnings, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should convert a rewrite only adding https into a RedirectScheme", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `rewrite ^(.*)$ https://$host$1 redirect;`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		redirect := findMiddleware(t, ctx, "https-redirect").Spec.RedirectScheme
+		if redirect == nil || redirect.Scheme != "https" || redirect.Permanent {
+			t.Fatalf("expected a temporary https RedirectScheme, got %+v", redirect)
+		}
+	})
+
+	t.Run("should keep the URI captured without its leading slash", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `rewrite ^/(.*)$ https://example.com/$1 permanent;`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		redirect := findMiddleware(t, ctx, "rewrite-redirect").Spec.RedirectRegex
+		if redirect == nil || redirect.Regex != `^https?://[^/]+/(.*)$` || redirect.Replacement != "https://example.com/${1}" {
+			t.Fatalf("expected the URI without its leading slash appended to https://example.com/, got %+v", redirect)
+		}
+
+		replacement := strings.ReplaceAll(redirect.Replacement, "${1}", "$1")
+		if got := regexp.MustCompile(redirect.Regex).ReplaceAllString("https://a.test/foo/bar", replacement); got != "https://example.com/foo/bar" {
+			t.Errorf("expected https://example.com/foo/bar, got %s", got)
+		}
+	})
+
+	t.Run("should treat $1 without a capture group as empty", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `rewrite ^ https://example.com$1 permanent;`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		redirect := findMiddleware(t, ctx, "rewrite-redirect").Spec.RedirectRegex
+		if redirect == nil || redirect.Regex != "^.*$" || redirect.Replacement != "https://example.com" {
+			t.Fatalf("expected a fixed redirect to https://example.com, got %+v", redirect)
+		}
+	})
+
+	t.Run("should not consume path rewrites", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `rewrite ^/old/(.*)$ /new/$1 break;`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.Middlewares) != 0 {
+			t.Errorf("expected no middlewares, got %d", len(ctx.Result.Middlewares))
+		}
+
+		if !hasWarning(ctx, "unsupported directive") {
+			t.Errorf("expected the path rewrite to be reported as unsupported, got %v", ctx.Result.Warnings)
+		}
+	})
+}
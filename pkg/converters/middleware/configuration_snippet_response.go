@@ -0,0 +1,146 @@
+package middleware
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	traefik "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+/* ---------------- Response body handling ---------------- */
+
+// subFilterWarning reports a `sub_filter <from> <to>;` directive. Rewriting the
+// response body needs a plugin in Traefik OSS.
+func subFilterWarning(line string) string {
+	args := quotedArgs(line)
+
+	const subFilterArgs = 2
+
+	if len(args) < subFilterArgs {
+		return "failed to parse sub_filter directive: " + line
+	}
+
+	return fmt.Sprintf(
+		"sub_filter replaces %q with %q in response bodies; response body rewriting is not supported "+
+			"in Traefik OSS, use a plugin such as rewrite-body to replace the content",
+		args[0], args[1],
+	)
+}
+
+// subFilterPlaceholder scaffolds a rewrite-body plugin middleware from the
+// sub_filter directives. NGINX matches sub_filter strings literally, so they are
+// quoted into regexes; the plugin still has to be installed and verified.
+func subFilterPlaceholder(ctx configs.Context, lines []string, warnings *[]string) {
+	const subFilterArgs = 2
+
+	rewrites := make([]map[string]string, 0, len(lines))
+
+	for _, line := range lines {
+		args := quotedArgs(line)
+		if len(args) < subFilterArgs {
+			continue
+		}
+
+		rewrites = append(rewrites, map[string]string{
+			"regex":       regexp.QuoteMeta(args[0]),
+			"replacement": args[1],
+		})
+	}
+
+	if len(rewrites) == 0 {
+		return
+	}
+
+	mw, err := newPluginPlaceholder(ctx, "sub-filter", "rewritebody",
+		map[string]any{"rewrites": rewrites},
+		"install the github.com/traefik/plugin-rewritebody plugin and review the generated rewrites",
+	)
+	if err != nil {
+		*warnings = append(*warnings, "failed to generate sub_filter plugin placeholder: "+err.Error())
+
+		return
+	}
+
+	ctx.Result.Middlewares = append(ctx.Result.Middlewares, mw)
+
+	*warnings = append(*warnings,
+		"sub_filter was scaffolded as a rewritebody plugin placeholder middleware which needs manual configuration",
+	)
+}
+
+// convertBrotli recognises the brotli module directives. Traefik v3 compresses
+// with brotli through the Compress middleware, so `brotli on;` becomes a
+// Compress middleware; the per-directive tuning has no equivalent.
+// It returns the snippet lines that were not consumed.
+func convertBrotli(ctx configs.Context, lines []string) []string {
+	rest := make([]string, 0, len(lines))
+	enabled := false
+	found := false
+
+	for _, line := range lines {
+		name := directive(strings.ToLower(line))
+		if !strings.HasPrefix(name, "brotli") {
+			rest = append(rest, line)
+
+			continue
+		}
+
+		found = true
+		args := directiveArgs(line)
+
+		switch name {
+		case "brotli":
+			enabled = len(args) > 0 && strings.EqualFold(args[0], "on")
+		case "brotli_types":
+			ctx.Result.Warnings = append(ctx.Result.Warnings, fmt.Sprintf(
+				"brotli_types %s cannot be mapped; Traefik Compress middleware selects content types for all "+
+					"encodings at once, review includedContentTypes/excludedContentTypes manually",
+				strings.Join(args, " "),
+			))
+		default:
+			ctx.Result.Warnings = append(ctx.Result.Warnings,
+				name+" has no Traefik equivalent and was ignored",
+			)
+		}
+	}
+
+	if !found || !enabled {
+		return rest
+	}
+
+	ctx.Result.Middlewares = append(ctx.Result.Middlewares,
+		newCompressMiddleware(ctx, "compress", &traefik.Compress{
+			Encodings: []string{"br", "gzip"},
+		}),
+	)
+
+	ctx.Result.Warnings = append(ctx.Result.Warnings,
+		"brotli was mapped to a Traefik Compress middleware with br and gzip encodings; "+
+			"brotli compression requires Traefik v3, older versions only compress with gzip",
+	)
+
+	return rest
+}
+
+func newCompressMiddleware(
+	ctx configs.Context,
+	name string,
+	compress *traefik.Compress,
+) *traefik.Middleware {
+	return &traefik.Middleware{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: traefik.SchemeGroupVersion.String(),
+			Kind:       "Middleware",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mwName(ctx, name),
+			Namespace: ctx.Namespace,
+		},
+		Spec: traefik.MiddlewareSpec{
+			Compress: compress,
+		},
+	}
+}
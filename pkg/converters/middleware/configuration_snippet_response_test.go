@@ -0,0 +1,96 @@
+package middleware_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestConfigurationSnippets_subFilter(t *testing.T) {
+	t.Run("should warn about response body rewriting with the sub_filter arguments", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `sub_filter '<a href="http://example.com' '<a href="https://example.com';`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !hasWarning(ctx, `sub_filter replaces "<a href=\"http://example.com" with "<a href=\"https://example.com"`) {
+			t.Errorf("expected sub_filter warning, got %v", ctx.Result.Warnings)
+		}
+
+		if hasWarning(ctx, "unsupported directive") {
+			t.Errorf("expected no generic drop warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
+
+func TestConfigurationSnippets_brotli(t *testing.T) {
+	t.Run("should map brotli to a Compress middleware and warn about brotli_types", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `brotli on;
+brotli_types text/css application/json;`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		compress := findMiddleware(t, ctx, "compress").Spec.Compress
+		if compress == nil || len(compress.Encodings) == 0 || compress.Encodings[0] != "br" {
+			t.Errorf("expected Compress middleware preferring br, got %+v", compress)
+		}
+
+		if !hasWarning(ctx, "brotli_types text/css application/json cannot be mapped") {
+			t.Errorf("expected brotli_types warning, got %v", ctx.Result.Warnings)
+		}
+
+		if !hasWarning(ctx, "brotli compression requires Traefik v3") {
+			t.Errorf("expected brotli warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
+
+func TestConfigurationSnippets_subFilterPlaceholder(t *testing.T) {
+	t.Run("should scaffold a rewritebody plugin placeholder when placeholders are enabled", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `sub_filter 'http://example.com' 'https://example.com';`,
+		})
+		ctx.Options.PluginPlaceholders = true
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		mw := findMiddleware(t, ctx, "sub-filter")
+
+		if _, ok := mw.Spec.Plugin["rewritebody"]; !ok {
+			t.Fatalf("expected rewritebody plugin, got %v", mw.Spec.Plugin)
+		}
+
+		if !strings.Contains(string(mw.Spec.Plugin["rewritebody"].Raw), `"replacement":"https://example.com"`) {
+			t.Errorf("expected sub_filter replacement in plugin config, got %s", mw.Spec.Plugin["rewritebody"].Raw)
+		}
+
+		if !strings.HasPrefix(mw.Annotations[middleware.PlaceholderAnnotation], "manual configuration required") {
+			t.Errorf("expected placeholder annotation, got %v", mw.Annotations)
+		}
+	})
+
+	t.Run("should not scaffold a placeholder by default", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `sub_filter 'http://example.com' 'https://example.com';`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.Middlewares) != 0 {
+			t.Errorf("expected no middlewares, got %d", len(ctx.Result.Middlewares))
+		}
+	})
+}
@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+)
+
+/* ---------------- Static configuration guidance ---------------- */
+
+// directiveArgs returns the arguments of a single line directive, without the
+// directive name and the trailing semicolon.
+func directiveArgs(line string) []string {
+	fields := strings.Fields(strings.TrimSuffix(strings.TrimSpace(line), ";"))
+	if len(fields) < 2 {
+		return nil
+	}
+
+	return fields[1:]
+}
+
+// keepaliveGuidance explains where the NGINX client keep-alive tuning lives in
+// Traefik. Connection reuse is an entryPoint concern and cannot be set per Ingress.
+func keepaliveGuidance(ctx configs.Context, line string) string {
+	args := strings.Join(directiveArgs(line), " ")
+
+	switch directive(strings.ToLower(line)) {
+	case "keepalive_timeout":
+		return staticConfigGuidance(ctx, fmt.Sprintf(
+			"keepalive_timeout %s cannot be configured per Ingress in Traefik; set "+
+				"entryPoints.web.transport.respondingTimeouts.idleTimeout (e.g. %s) in Traefik static configuration",
+			args, firstArg(args),
+		), staticSetting{"entryPoints.web.transport.respondingTimeouts.idleTimeout", firstArg(args)})
+	default:
+		requests, err := strconv.Atoi(firstArg(args))
+		if err != nil {
+			return "failed to parse keepalive_requests directive (expected a number of requests): " + line
+		}
+
+		return staticConfigGuidance(ctx, fmt.Sprintf(
+			"keepalive_requests %s cannot be configured per Ingress in Traefik; set "+
+				"entryPoints.web.transport.keepAliveMaxRequests (e.g. %s) in Traefik static configuration",
+			args, firstArg(args),
+		), staticSetting{"entryPoints.web.transport.keepAliveMaxRequests", requests})
+	}
+}
+
+// resolverGuidance acknowledges the NGINX DNS resolver directives. The Traefik
+// Kubernetes providers route to Service endpoints, so no resolver is involved.
+func resolverGuidance(line string) string {
+	return fmt.Sprintf(
+		"%s %s is not applicable in the Traefik Kubernetes provider; backends are discovered "+
+			"through Kubernetes Services and no DNS resolver settings are needed",
+		directive(strings.ToLower(line)), strings.Join(directiveArgs(line), " "),
+	)
+}
+
+// headerBuffersGuidance explains that `large_client_header_buffers <count> <size>;`
+// has no per Ingress equivalent. Traefik limits the total size of the request
+// headers globally, so count*size is suggested as the entryPoint limit.
+func headerBuffersGuidance(ctx configs.Context, line string) string {
+	args := directiveArgs(line)
+	invalid := "failed to parse large_client_header_buffers directive (expected a count and a size): " + line
+
+	const headerBuffersArgs = 2
+
+	if len(args) != headerBuffersArgs {
+		return invalid
+	}
+
+	count, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return invalid
+	}
+
+	size, err := parseSizeBytes(args[1])
+	if err != nil {
+		return invalid
+	}
+
+	return staticConfigGuidance(ctx, fmt.Sprintf(
+		"large_client_header_buffers configures %d header buffers of %s (%d bytes) which cannot be configured per Ingress in Traefik; "+
+			"header buffer sizes are set globally with entryPoints.<name>.http.maxHeaderBytes (e.g. %d) in Traefik static configuration",
+		count, args[1], size, count*size,
+	), staticSetting{"entryPoints.web.http.maxHeaderBytes", count * size})
+}
+
+// trustedIPsGuidance aggregates the `set_real_ip_from` sources of a snippet into
+// a single guidance message. Traefik only trusts forwarded headers per entryPoint,
+// so the sources have to be listed in forwardedHeaders.trustedIPs.
+func trustedIPsGuidance(ctx configs.Context, sources []string) []string {
+	messages := make([]string, 0)
+
+	ranges, invalid := parseSourceRanges(sources)
+	if len(invalid) > 0 {
+		messages = append(messages,
+			"set_real_ip_from entries are not valid IPs or CIDRs and were dropped: "+strings.Join(invalid, ", "),
+		)
+	}
+
+	if len(ranges) == 0 {
+		return messages
+	}
+
+	return append(messages, staticConfigGuidance(ctx, fmt.Sprintf(
+		"set_real_ip_from cannot be configured per Ingress in Traefik; trust the forwarded headers of these sources with "+
+			"entryPoints.web.forwardedHeaders.trustedIPs [%s] in Traefik static configuration",
+		strings.Join(ranges, ", "),
+	), staticSetting{"entryPoints.web.forwardedHeaders.trustedIPs", ranges}))
+}
+
+func firstArg(args string) string {
+	fields := strings.Fields(args)
+	if len(fields) == 0 {
+		return ""
+	}
+
+	return fields[0]
+}
@@ -0,0 +1,139 @@
+package middleware_test
+
+import (
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestConfigurationSnippets_keepalive(t *testing.T) {
+	t.Run("should emit entryPoint guidance for keepalive directives", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `keepalive_timeout 75s;
+keepalive_requests 1000;`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !hasWarning(ctx, "respondingTimeouts.idleTimeout (e.g. 75s)") {
+			t.Errorf("expected idleTimeout guidance, got %v", ctx.Result.Warnings)
+		}
+
+		if !hasWarning(ctx, "keepAliveMaxRequests (e.g. 1000)") {
+			t.Errorf("expected keepAliveMaxRequests guidance, got %v", ctx.Result.Warnings)
+		}
+
+		if hasWarning(ctx, "unsupported directive") {
+			t.Errorf("expected no generic drop warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
+
+func TestConfigurationSnippets_keepaliveRequests(t *testing.T) {
+	t.Run("should report an invalid keepalive_requests value", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `keepalive_requests many;`,
+		})
+		ctx.Options.SuggestYAML = true
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !hasWarning(ctx, "failed to parse keepalive_requests directive") {
+			t.Errorf("expected parse warning, got %v", ctx.Result.Warnings)
+		}
+
+		if hasWarning(ctx, "keepAliveMaxRequests: 0") {
+			t.Errorf("expected no zero keepAliveMaxRequests suggestion, got %v", ctx.Result.Warnings)
+		}
+	})
+}
+
+func TestConfigurationSnippets_resolver(t *testing.T) {
+	t.Run("should acknowledge the resolver directive", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `resolver 10.0.0.10 valid=30s;`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !hasWarning(ctx, "resolver 10.0.0.10 valid=30s is not applicable in the Traefik Kubernetes provider") {
+			t.Errorf("expected resolver warning, got %v", ctx.Result.Warnings)
+		}
+
+		if hasWarning(ctx, "unsupported directive") {
+			t.Errorf("expected no generic drop warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
+
+func TestConfigurationSnippets_largeClientHeaderBuffers(t *testing.T) {
+	t.Run("should emit global header size guidance with the parsed buffers", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `large_client_header_buffers 4 16k;`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !hasWarning(ctx, "configures 4 header buffers of 16k (16384 bytes)") {
+			t.Errorf("expected parsed buffer details, got %v", ctx.Result.Warnings)
+		}
+
+		if !hasWarning(ctx, "entryPoints.<name>.http.maxHeaderBytes (e.g. 65536)") {
+			t.Errorf("expected maxHeaderBytes guidance, got %v", ctx.Result.Warnings)
+		}
+
+		if hasWarning(ctx, "unsupported directive") {
+			t.Errorf("expected no generic drop warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
+
+func TestConfigurationSnippets_setRealIPFrom(t *testing.T) {
+	t.Run("should aggregate set_real_ip_from sources into a single trustedIPs guidance", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `set_real_ip_from 10.0.0.0/8;
+set_real_ip_from 192.168.0.0/16;
+set_real_ip_from 172.16.0.1;`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.Warnings) != 1 {
+			t.Fatalf("expected a single aggregated warning, got %v", ctx.Result.Warnings)
+		}
+
+		if !hasWarning(ctx, "forwardedHeaders.trustedIPs [10.0.0.0/8, 192.168.0.0/16, 172.16.0.1]") {
+			t.Errorf("expected trustedIPs guidance, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should drop invalid sources with a warning", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `set_real_ip_from 10.0.0.0/8;
+set_real_ip_from 10.0.0.0/99;`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !hasWarning(ctx, "set_real_ip_from entries are not valid IPs or CIDRs and were dropped: 10.0.0.0/99") {
+			t.Errorf("expected invalid source warning, got %v", ctx.Result.Warnings)
+		}
+
+		if !hasWarning(ctx, "forwardedHeaders.trustedIPs [10.0.0.0/8]") {
+			t.Errorf("expected trustedIPs guidance, got %v", ctx.Result.Warnings)
+		}
+	})
+}
@@ -0,0 +1,266 @@
+package middleware_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestConfigurationSnippets_unsupported(t *testing.T) {
+	t.Run("should warn that gzip_static is not supported", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `gzip_static on;`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !hasWarning(ctx, "gzip_static serves pre-compressed .gz files") {
+			t.Errorf("expected gzip_static warning, got %v", ctx.Result.Warnings)
+		}
+
+		if hasWarning(ctx, "unsupported directive") {
+			t.Errorf("expected no generic drop warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
+
+func TestConfigurationSnippets_enterpriseCaching(t *testing.T) {
+	t.Run("should mark cache bypass directives as Traefik Enterprise features", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `proxy_cache_bypass $http_pragma;
+proxy_no_cache $http_pragma;`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		for _, name := range []string{"proxy_cache_bypass", "proxy_no_cache"} {
+			if !hasWarning(ctx, name+" is not supported in Traefik OSS. Traefik Enterprise provides an alternative") {
+				t.Errorf("expected enterprise warning for %s, got %v", name, ctx.Result.Warnings)
+			}
+		}
+	})
+}
+
+func TestConfigurationSnippets_emptyResponseHeader(t *testing.T) {
+	t.Run("should note that an empty more_set_headers value removes the header", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `more_set_headers "X-Powered-By: ";`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		headers := findMiddleware(t, ctx, "configuration-snippet").Spec.Headers
+		if val, ok := headers.CustomResponseHeaders["X-Powered-By"]; !ok || val != "" {
+			t.Errorf("expected X-Powered-By to be set to an empty value, got %v", headers.CustomResponseHeaders)
+		}
+
+		if !hasWarning(ctx, "response header X-Powered-By is set to an empty value; Traefik treats this as removal") {
+			t.Errorf("expected removal note, got %v", ctx.Result.Warnings)
+		}
+	})
+}
+
+func TestConfigurationSnippets_crlf(t *testing.T) {
+	t.Run("should parse CRLF-delimited snippets like LF-delimited ones", func(t *testing.T) {
+		lf := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): "add_header X-Frame-Options \"DENY\";\nadd_header X-Custom \"value\";\n",
+		})
+		crlf := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): "add_header X-Frame-Options \"DENY\";\r\nadd_header X-Custom \"value\";\r\n",
+		})
+
+		for _, ctx := range []configs.Context{lf, crlf} {
+			if err := middleware.ConfigurationSnippets(ctx); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		expected := findMiddleware(t, lf, "configuration-snippet").Spec.Headers
+		actual := findMiddleware(t, crlf, "configuration-snippet").Spec.Headers
+
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("expected CRLF snippet to produce %+v, got %+v", expected, actual)
+		}
+
+		if actual.CustomResponseHeaders["X-Custom"] != "value" {
+			t.Errorf("expected X-Custom header without carriage return, got %q", actual.CustomResponseHeaders["X-Custom"])
+		}
+
+		if !reflect.DeepEqual(lf.Result.Warnings, crlf.Result.Warnings) {
+			t.Errorf("expected identical warnings, got %v and %v", lf.Result.Warnings, crlf.Result.Warnings)
+		}
+	})
+}
+
+func TestConfigurationSnippets_comments(t *testing.T) {
+	t.Run("should strip inline comments but keep # inside quoted values", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `# response headers
+add_header X-Foo bar; # some comment
+add_header X-Color "#fff";`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		headers := findMiddleware(t, ctx, "configuration-snippet").Spec.Headers
+
+		expected := map[string]string{"X-Foo": "bar", "X-Color": "#fff"}
+		if !reflect.DeepEqual(expected, headers.CustomResponseHeaders) {
+			t.Errorf("expected headers %v, got %v", expected, headers.CustomResponseHeaders)
+		}
+
+		if hasWarning(ctx, "unsupported directive") {
+			t.Errorf("expected comment lines to be ignored, got %v", ctx.Result.Warnings)
+		}
+	})
+}
+
+func TestConfigurationSnippets_multipleDirectivesPerLine(t *testing.T) {
+	t.Run("should process every directive of a single line", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `add_header X-A a; add_header X-B "b; c";`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		headers := findMiddleware(t, ctx, "configuration-snippet").Spec.Headers
+
+		expected := map[string]string{"X-A": "a", "X-B": "b; c"}
+		if !reflect.DeepEqual(expected, headers.CustomResponseHeaders) {
+			t.Errorf("expected headers %v, got %v", expected, headers.CustomResponseHeaders)
+		}
+	})
+}
+
+func TestConfigurationSnippets_quotedAddHeader(t *testing.T) {
+	t.Run("should parse a quoted header name with a quoted multi word value", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `add_header "X-Served-By" "edge proxy  one" always;`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		headers := findMiddleware(t, ctx, "configuration-snippet").Spec.Headers
+
+		expected := map[string]string{"X-Served-By": "edge proxy  one"}
+		if !reflect.DeepEqual(expected, headers.CustomResponseHeaders) {
+			t.Errorf("expected headers %v, got %v", expected, headers.CustomResponseHeaders)
+		}
+	})
+}
+
+func TestConfigurationSnippets_mixedCaseDirectives(t *testing.T) {
+	t.Run("should convert header directives written in mixed case", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `Add_Header X-Frame-Options "DENY";
+More_Set_Headers "X-Custom: value";`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		headers := findMiddleware(t, ctx, "configuration-snippet").Spec.Headers
+
+		expected := map[string]string{"X-Custom": "value"}
+		if !reflect.DeepEqual(headers.CustomResponseHeaders, expected) {
+			t.Errorf("expected %v, got %v", expected, headers.CustomResponseHeaders)
+		}
+
+		if headers.CustomFrameOptionsValue != "DENY" {
+			t.Errorf("expected frame options DENY, got %q", headers.CustomFrameOptionsValue)
+		}
+
+		if hasWarning(ctx, "failed to parse header directive") {
+			t.Errorf("expected no parse failures, got %v", ctx.Result.Warnings)
+		}
+	})
+}
+
+func TestConfigurationSnippets_multiLineQuotedValue(t *testing.T) {
+	t.Run("should join a quoted header value spanning several lines", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `add_header Content-Security-Policy "default-src 'self';
+    script-src 'self' cdn.example.com;
+    img-src *";
+add_header X-Frame-Options "DENY";`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		headers := findMiddleware(t, ctx, "configuration-snippet").Spec.Headers
+
+		expected := "default-src 'self'; script-src 'self' cdn.example.com; img-src *"
+		if got := headers.ContentSecurityPolicy; got != expected {
+			t.Errorf("expected CSP %q, got %q", expected, got)
+		}
+
+		if headers.CustomFrameOptionsValue != "DENY" {
+			t.Errorf("expected X-Frame-Options to be parsed separately, got %q", headers.CustomFrameOptionsValue)
+		}
+
+		if hasWarning(ctx, "failed to parse") || hasWarning(ctx, "unsupported directive") {
+			t.Errorf("expected no parse failures, got %v", ctx.Result.Warnings)
+		}
+	})
+}
+
+func TestConfigurationSnippets_messyTerminators(t *testing.T) {
+	t.Run("should collapse trailing whitespace and repeated semicolons", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): "add_header X-Foo bar ;\nadd_header X-Bar \"baz\";;  \n",
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		headers := findMiddleware(t, ctx, "configuration-snippet").Spec.Headers
+
+		expected := map[string]string{"X-Foo": "bar", "X-Bar": "baz"}
+		if !reflect.DeepEqual(headers.CustomResponseHeaders, expected) {
+			t.Errorf("expected %v, got %v", expected, headers.CustomResponseHeaders)
+		}
+
+		if hasWarning(ctx, "unsupported directive") {
+			t.Errorf("expected no stray directive warnings, got %v", ctx.Result.Warnings)
+		}
+	})
+}
+
+func TestConfigurationSnippets_internal(t *testing.T) {
+	t.Run("should warn that the internal restriction cannot be expressed", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `internal;`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !hasWarning(ctx, "internal marks the location as reachable only through internal redirects") {
+			t.Errorf("expected internal warning, got %v", ctx.Result.Warnings)
+		}
+
+		if hasWarning(ctx, "unsupported directive") {
+			t.Errorf("expected no generic drop warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
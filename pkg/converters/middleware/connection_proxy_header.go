@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+/* ---------------- CONNECTION PROXY HEADER ---------------- */
+
+// ConnectionProxyHeader handles the below annotations.
+// Annotations:
+//   - "nginx.ingress.kubernetes.io/connection-proxy-header"
+func ConnectionProxyHeader(ctx configs.Context) {
+	ctx.Log.Debug("running converter ConnectionProxyHeader")
+
+	ann := string(models.ConnectionProxyHeader)
+
+	val, ok := ctx.Annotations[ann]
+	if !ok || strings.TrimSpace(val) == "" {
+		return
+	}
+
+	val = strings.TrimSpace(val)
+
+	if strings.EqualFold(val, "upgrade") || val == "$connection_upgrade" {
+		ctx.ReportDefault(ann, "Traefik handles websocket upgrades out of the box")
+
+		return
+	}
+
+	ctx.Result.Middlewares = append(ctx.Result.Middlewares,
+		newHeadersMiddleware(ctx, "connection-proxy-header", &dynamic.Headers{
+			CustomRequestHeaders: map[string]string{
+				"Connection": val,
+			},
+		}),
+	)
+
+	msg := "connection-proxy-header sets the hop-by-hop header Connection: " + val + ", Traefik removes hop-by-hop " +
+		"headers before forwarding and manages backend connections itself; tune the connection reuse with the " +
+		"ServersTransport (e.g. upstream-keepalive-connections) instead"
+
+	ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+	ctx.ReportWarning(ann, msg)
+}
@@ -0,0 +1,36 @@
+package middleware_test
+
+import (
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestConnectionProxyHeader(t *testing.T) {
+	t.Run("should set the Connection request header and warn about hop-by-hop headers", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{string(models.ConnectionProxyHeader): "keep-alive"})
+
+		middleware.ConnectionProxyHeader(ctx)
+
+		mw := findMiddleware(t, ctx, "connection-proxy-header")
+
+		if got := mw.Spec.Headers.CustomRequestHeaders["Connection"]; got != "keep-alive" {
+			t.Errorf("expected Connection keep-alive, got %q", got)
+		}
+
+		if !hasWarning(ctx, "hop-by-hop") {
+			t.Errorf("expected hop-by-hop warning, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should ignore websocket upgrades", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{string(models.ConnectionProxyHeader): "upgrade"})
+
+		middleware.ConnectionProxyHeader(ctx)
+
+		if len(ctx.Result.Middlewares) != 0 {
+			t.Errorf("expected no middlewares, got %d", len(ctx.Result.Middlewares))
+		}
+	})
+}
@@ -6,6 +6,15 @@ import (
 	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
 )
 
+const (
+	// NoteAnnotation carries an informational note about a generated middleware.
+	NoteAnnotation = "nginx-traefik-converter/note"
+
+	// PlaceholderAnnotation marks middlewares which were generated as a starting
+	// point for a Traefik plugin and must be configured manually before use.
+	PlaceholderAnnotation = "nginx-traefik-converter/placeholder"
+)
+
 /* ---------------- WARNINGS ---------------- */
 
 // Warnings adds warnings to the parsed annotations if any.
@@ -23,3 +32,15 @@ func Warnings(ctx configs.Context) {
 func mwName(ctx configs.Context, suffix string) string {
 	return ctx.IngressName + "-" + suffix
 }
+
+func hasMiddleware(ctx configs.Context, suffix string) bool {
+	name := mwName(ctx, suffix)
+
+	for _, mw := range ctx.Result.Middlewares {
+		if mw.GetName() == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,52 @@
+package middleware_test
+
+import (
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	traefik "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestContext(annotations map[string]string) configs.Context {
+	ingress := &netv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test",
+			Namespace:   "default",
+			Annotations: annotations,
+		},
+	}
+
+	ctx := configs.New(ingress, configs.NewResult(), configs.NewOptions(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx.StartIngressReport(ingress.Namespace, ingress.Name)
+
+	return *ctx
+}
+
+func findMiddleware(t *testing.T, ctx configs.Context, suffix string) *traefik.Middleware {
+	t.Helper()
+
+	for _, mw := range ctx.Result.Middlewares {
+		if mw.GetName() == ctx.IngressName+"-"+suffix {
+			return mw
+		}
+	}
+
+	t.Fatalf("middleware %q not found", suffix)
+
+	return nil
+}
+
+func hasWarning(ctx configs.Context, substr string) bool {
+	for _, warning := range ctx.Result.Warnings {
+		if strings.Contains(warning, substr) {
+			return true
+		}
+	}
+
+	return false
+}
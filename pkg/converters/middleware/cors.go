@@ -23,8 +23,13 @@ import (
 func CORS(ctx configs.Context) error {
 	ctx.Log.Debug("running converter CORS")
 
-	if ctx.Annotations[string(models.EnableCORS)] != "true" {
-		ctx.ReportIgnored(string(models.EnableCORS), "enable-cors was not set to true")
+	enabled, ok := ctx.Annotations[string(models.EnableCORS)]
+	if !ok {
+		return nil
+	}
+
+	if enabled != "true" {
+		ctx.ReportDefault(string(models.EnableCORS), "enable-cors was not set to true")
 
 		return nil
 	}
@@ -46,13 +51,13 @@ func CORS(ctx configs.Context) error {
 	if v := ctx.Annotations[string(models.CorsAllowHeaders)]; v != "" {
 		headers.AccessControlAllowHeaders = headersNeat(v)
 
-		ctx.ReportConverted(string(models.CorsAllowMethods))
+		ctx.ReportConverted(string(models.CorsAllowHeaders))
 	}
 
 	if v := ctx.Annotations[string(models.CorsAllowCredentials)]; v == "true" {
 		headers.AccessControlAllowCredentials = true
 
-		ctx.ReportConverted(string(models.CorsAllowMethods))
+		ctx.ReportConverted(string(models.CorsAllowCredentials))
 	}
 
 	if v := ctx.Annotations[string(models.CorsMaxAge)]; v != "" {
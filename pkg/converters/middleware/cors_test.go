@@ -0,0 +1,36 @@
+package middleware_test
+
+import (
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestCORS(t *testing.T) {
+	t.Run("should not report enable-cors when the annotation is not set", func(t *testing.T) {
+		ctx := newTestContext(nil)
+		ctx.Options.Strict = true
+
+		if err := middleware.CORS(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := ctx.Result.Err(); err != nil {
+			t.Errorf("expected no strict mode error, got %v", err)
+		}
+	})
+
+	t.Run("should report enable-cors as ignored when it is not true", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{string(models.EnableCORS): "false"})
+
+		if err := middleware.CORS(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		entries := ctx.Result.IngressReport.Entries
+		if len(entries) != 1 || entries[0].Name != string(models.EnableCORS) {
+			t.Errorf("expected a single enable-cors report entry, got %v", entries)
+		}
+	})
+}
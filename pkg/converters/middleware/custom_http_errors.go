@@ -0,0 +1,215 @@
+package middleware
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+	traefik "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+)
+
+/* ---------------- CUSTOM HTTP ERRORS ---------------- */
+
+// CustomHTTPErrors handles the below annotations.
+// Annotations:
+//   - "nginx.ingress.kubernetes.io/custom-http-errors"
+//   - "nginx.ingress.kubernetes.io/default-backend"
+func CustomHTTPErrors(ctx configs.Context) {
+	ctx.Log.Debug("running converter CustomHTTPErrors")
+
+	annErrors := string(models.CustomHTTPErrors)
+	annBackend := string(models.DefaultBackend)
+
+	codes := strings.TrimSpace(ctx.Annotations[annErrors])
+	backend := strings.TrimSpace(ctx.Annotations[annBackend])
+
+	if codes == "" {
+		// without custom-http-errors the default-backend is served by a catch-all
+		// router of the IngressRoute.
+		return
+	}
+
+	service, msg, ok := defaultBackendService(ctx, backend)
+	if !ok {
+		msg = "custom-http-errors requires a service to serve the error pages; " +
+			"set the default-backend annotation or spec.defaultBackend"
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportSkipped(annErrors, msg)
+
+		return
+	}
+
+	statuses := make([]string, 0)
+
+	for _, code := range strings.Split(codes, ",") {
+		if code = strings.TrimSpace(code); code != "" {
+			statuses = append(statuses, code)
+		}
+	}
+
+	ctx.Result.Middlewares = append(ctx.Result.Middlewares,
+		newErrorsMiddleware(ctx, "custom-http-errors", &traefik.ErrorPage{
+			Status:  statuses,
+			Service: service,
+			Query:   "/",
+		}),
+	)
+
+	ctx.ReportConverted(annErrors)
+
+	switch {
+	case msg != "":
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportWarning(annBackend, msg)
+	case backend != "":
+		ctx.ReportConverted(annBackend)
+	}
+}
+
+// defaultBackendService resolves the service named by the default-backend
+// annotation, falling back to the Ingress backend. The annotation names a Service
+// of the Ingress namespace, a warning is returned when its port had to be assumed.
+func defaultBackendService(ctx configs.Context, backend string) (traefik.Service, string, bool) {
+	if backend == "" {
+		service, ok := errorPageService(ctx.Ingress)
+
+		return service, "", ok
+	}
+
+	svc, found := ctx.DefaultBackendService(backend)
+	if !found {
+		return serviceFromBackend(svc), fmt.Sprintf("default-backend %s is not a backend of the Ingress, "+
+			"port %d was assumed for the error pages while ingress-nginx serves the first port of the Service",
+			backend, svc.Port.Number), true
+	}
+
+	return serviceFromBackend(svc), "", true
+}
+
+/* ---------------- ERRORS MERGE ---------------- */
+
+// MergeErrors combines the Errors middlewares generated from custom-http-errors,
+// default-backend and snippet error_page directives which query the same page of the
+// same service into one. A Traefik Errors middleware has a single service and query, so
+// error pages of other services or paths are kept as separate middlewares, which is
+// reported once; status codes handled by several of them are reported as well.
+func MergeErrors(ctx configs.Context) {
+	ctx.Log.Debug("running converter MergeErrors")
+
+	indexes := make([]int, 0)
+
+	for index, mw := range ctx.Result.Middlewares {
+		if mw.Spec.Errors != nil {
+			indexes = append(indexes, index)
+		}
+	}
+
+	if len(indexes) < 2 {
+		return
+	}
+
+	// merged holds one Errors spec per service and query, keyed by the index of the
+	// first middleware of the group which it replaces.
+	merged := make(map[int]*traefik.ErrorPage)
+	groups := make([]int, 0)
+
+	for _, index := range indexes {
+		spec := ctx.Result.Middlewares[index].Spec.Errors
+
+		group := slices.IndexFunc(groups, func(first int) bool {
+			return sameErrorPage(merged[first], spec)
+		})
+
+		if group < 0 {
+			groups = append(groups, index)
+			merged[index] = &traefik.ErrorPage{Service: spec.Service, Query: spec.Query}
+			group = len(groups) - 1
+		}
+
+		target := merged[groups[group]]
+
+		for _, status := range spec.Status {
+			if !slices.Contains(target.Status, status) {
+				target.Status = append(target.Status, status)
+			}
+		}
+
+		for status, code := range spec.StatusRewrites {
+			if target.StatusRewrites == nil {
+				target.StatusRewrites = make(map[string]int)
+			}
+
+			target.StatusRewrites[status] = code
+		}
+	}
+
+	names := make(map[int]string, len(groups))
+
+	for group, first := range groups {
+		names[first] = "errors"
+		if group > 0 {
+			names[first] = fmt.Sprintf("errors-%d", group)
+		}
+	}
+
+	if len(groups) > 1 {
+		separate := make([]string, 0, len(groups))
+		for _, first := range groups {
+			separate = append(separate, fmt.Sprintf("%s (status %s, query %s)",
+				mwName(ctx, names[first]), strings.Join(merged[first].Status, ","), merged[first].Query))
+		}
+
+		ctx.Result.Notes = append(ctx.Result.Notes, fmt.Sprintf(
+			"the error pages query different services or paths and are kept as separate Errors middlewares: %s",
+			strings.Join(separate, "; "),
+		))
+	}
+
+	warnOverlappingErrors(ctx, groups, merged, names)
+
+	middlewares := make([]*traefik.Middleware, 0, len(ctx.Result.Middlewares)-len(indexes)+len(groups))
+
+	for index, mw := range ctx.Result.Middlewares {
+		switch {
+		case merged[index] != nil:
+			middlewares = append(middlewares, newErrorsMiddleware(ctx, names[index], merged[index]))
+		case mw.Spec.Errors != nil:
+			continue
+		default:
+			middlewares = append(middlewares, mw)
+		}
+	}
+
+	ctx.Result.Middlewares = middlewares
+}
+
+// sameErrorPage tells whether two Errors specs query the same page of the same service.
+func sameErrorPage(spec, other *traefik.ErrorPage) bool {
+	return spec.Service.Name == other.Service.Name &&
+		spec.Service.Namespace == other.Service.Namespace &&
+		spec.Service.Port == other.Service.Port &&
+		spec.Query == other.Query
+}
+
+// warnOverlappingErrors reports the status codes handled by several Errors middlewares,
+// the page served for them depends on the order of the middlewares in the router chain.
+func warnOverlappingErrors(ctx configs.Context, groups []int, merged map[int]*traefik.ErrorPage, names map[int]string) {
+	for group, first := range groups {
+		for _, other := range groups[group+1:] {
+			for _, status := range merged[first].Status {
+				if !slices.Contains(merged[other].Status, status) {
+					continue
+				}
+
+				ctx.Result.Warnings = append(ctx.Result.Warnings, fmt.Sprintf(
+					"status %s is handled by the Errors middlewares %s and %s which serve different error pages; "+
+						"the page served depends on the order of the middlewares in the router chain",
+					status, mwName(ctx, names[first]), mwName(ctx, names[other]),
+				))
+			}
+		}
+	}
+}
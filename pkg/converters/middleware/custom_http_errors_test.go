@@ -0,0 +1,101 @@
+package middleware_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestMergeErrors(t *testing.T) {
+	t.Run("should merge annotation and snippet error handling querying the same page into one Errors middleware", func(t *testing.T) {
+		ctx := withDefaultBackend(newTestContext(map[string]string{
+			string(models.CustomHTTPErrors):     "404,503",
+			string(models.ConfigurationSnippet): `error_page 500 503 /;`,
+		}))
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		middleware.CustomHTTPErrors(ctx)
+		middleware.MergeErrors(ctx)
+
+		if len(ctx.Result.Middlewares) != 1 {
+			t.Fatalf("expected a single middleware, got %d", len(ctx.Result.Middlewares))
+		}
+
+		errors := findMiddleware(t, ctx, "errors").Spec.Errors
+		if expected := []string{"500", "503", "404"}; !reflect.DeepEqual(expected, errors.Status) {
+			t.Errorf("expected status %v, got %v", expected, errors.Status)
+		}
+
+		if errors.Service.Name != "app" || errors.Query != "/" {
+			t.Errorf("unexpected Errors middleware: %+v", errors)
+		}
+	})
+
+	t.Run("should keep the annotation and snippet error pages as separate Errors middlewares", func(t *testing.T) {
+		ctx := withDefaultBackend(newTestContext(map[string]string{
+			string(models.CustomHTTPErrors):     "404,503",
+			string(models.ConfigurationSnippet): `error_page 500 503 /50x.html;`,
+		}))
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		middleware.CustomHTTPErrors(ctx)
+		middleware.MergeErrors(ctx)
+
+		if len(ctx.Result.Middlewares) != 2 {
+			t.Fatalf("expected two Errors middlewares, got %d", len(ctx.Result.Middlewares))
+		}
+
+		if query := findMiddleware(t, ctx, "errors").Spec.Errors.Query; query != "/50x.html" {
+			t.Errorf("expected the snippet error page, got %q", query)
+		}
+
+		if query := findMiddleware(t, ctx, "errors-1").Spec.Errors.Query; query != "/" {
+			t.Errorf("expected the custom-http-errors error page, got %q", query)
+		}
+
+		if len(ctx.Result.Notes) != 1 || !strings.Contains(ctx.Result.Notes[0], "kept as separate Errors middlewares: test-errors") {
+			t.Errorf("expected a single note about the separate middlewares, got %v", ctx.Result.Notes)
+		}
+
+		if !hasWarning(ctx, "status 503 is handled by the Errors middlewares") {
+			t.Errorf("expected overlapping status warning, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should keep the Errors middlewares of different backends", func(t *testing.T) {
+		ctx := withDefaultBackend(newTestContext(map[string]string{
+			string(models.CustomHTTPErrors):     "404",
+			string(models.DefaultBackend):       "errors-svc",
+			string(models.ConfigurationSnippet): `error_page 500 /50x.html;`,
+		}))
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		middleware.CustomHTTPErrors(ctx)
+		middleware.MergeErrors(ctx)
+
+		service := findMiddleware(t, ctx, "errors-1").Spec.Errors.Service
+		if service.Name != "errors-svc" || service.Namespace != "" || service.Port.IntValue() != 80 {
+			t.Errorf("expected the default-backend service on port 80, got %+v", service)
+		}
+
+		if !hasWarning(ctx, "port 80 was assumed") {
+			t.Errorf("expected assumed port warning, got %v", ctx.Result.Warnings)
+		}
+
+		if hasWarning(ctx, "is handled by the Errors middlewares") {
+			t.Errorf("expected no overlapping status warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
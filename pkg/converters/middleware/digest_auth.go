@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+	traefik "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+/* ---------------- DIGEST AUTH ---------------- */
+
+// defaultDigestRealm is the realm Traefik DigestAuth uses when none is configured.
+const defaultDigestRealm = "traefik"
+
+// DigestAuth handles the below annotations.
+// Annotations:
+//   - "nginx.ingress.kubernetes.io/auth-type"
+//   - "nginx.ingress.kubernetes.io/auth-secret"
+//   - "nginx.ingress.kubernetes.io/auth-secret-type"
+//   - "nginx.ingress.kubernetes.io/auth-realm"
+func DigestAuth(ctx configs.Context) {
+	ctx.Log.Debug("running converter DigestAuth")
+
+	if val, ok := ctx.Annotations[string(models.AuthType)]; !ok || val != "digest" {
+		return
+	}
+
+	secret, secretWarning, ok := authSecretName(ctx)
+	if !ok {
+		return
+	}
+
+	annSecretType := string(models.AuthSecretType)
+
+	if secretType, ok := ctx.Annotations[annSecretType]; ok {
+		if strings.TrimSpace(secretType) != authSecretTypeFile {
+			msg := fmt.Sprintf("auth-secret-type %q is not supported for digest auth, "+
+				"the secret must hold a htdigest file in a single key", secretType)
+
+			ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+			ctx.ReportWarning(annSecretType, msg)
+		} else {
+			ctx.ReportConverted(annSecretType)
+		}
+	}
+
+	// Traefik only accepts the htdigest entries of its configured realm.
+	realm := strings.TrimSpace(ctx.Annotations[string(models.AuthRealm)])
+	if realm == "" {
+		realm = defaultDigestRealm
+	}
+
+	secretWarnings := digestSecretWarnings(ctx, secret, realm)
+	if secretWarning != "" {
+		secretWarnings = append([]string{secretWarning}, secretWarnings...)
+	}
+
+	ctx.Result.Middlewares = append(ctx.Result.Middlewares, &traefik.Middleware{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: traefik.SchemeGroupVersion.String(),
+			Kind:       "Middleware",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mwName(ctx, "digestauth"),
+			Namespace: ctx.Namespace,
+		},
+		Spec: traefik.MiddlewareSpec{
+			DigestAuth: &traefik.DigestAuth{
+				Secret: secret,
+				Realm:  realm,
+			},
+		},
+	})
+
+	ctx.ReportConverted(string(models.AuthType))
+
+	if len(secretWarnings) > 0 {
+		ctx.Result.Warnings = append(ctx.Result.Warnings, secretWarnings...)
+		ctx.ReportWarning(string(models.AuthSecret), strings.Join(secretWarnings, "; "))
+	} else {
+		ctx.ReportConverted(string(models.AuthSecret))
+	}
+
+	if _, ok := ctx.Annotations[string(models.AuthRealm)]; ok {
+		ctx.ReportConverted(string(models.AuthRealm))
+	}
+}
+
+// digestSecretWarnings checks that the digest auth secret holds a single key, as
+// Traefik reads the htdigest file from the only key of the secret. Without access
+// to the cluster the layout cannot be checked, so a note describes it instead.
+func digestSecretWarnings(ctx configs.Context, secret, realm string) []string {
+	namespace := authSecretNamespace(ctx)
+
+	if ctx.Options.Secrets == nil {
+		ctx.Result.Notes = append(ctx.Result.Notes, fmt.Sprintf(
+			"digest auth secret %q must only contain a htdigest file with 'user:%s:hash' entries, "+
+				"entries of other realms are rejected by Traefik", secret, realm,
+		))
+
+		return nil
+	}
+
+	keys, err := ctx.Options.Secrets(namespace, secret)
+	if err != nil {
+		return []string{fmt.Sprintf("reading digest auth secret %s/%s failed: %s", namespace, secret, err.Error())}
+	}
+
+	if len(keys) != 1 {
+		return []string{fmt.Sprintf("digest auth secret %s/%s has %d keys, Traefik expects a single key holding "+
+			"a htdigest file with 'user:%s:hash' entries", namespace, secret, len(keys), realm)}
+	}
+
+	return nil
+}
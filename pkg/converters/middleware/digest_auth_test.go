@@ -0,0 +1,123 @@
+package middleware_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestDigestAuth(t *testing.T) {
+	t.Run("should reference the htdigest secret and realm", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.AuthType):   "digest",
+			string(models.AuthSecret): "digest-auth",
+			string(models.AuthRealm):  "private",
+		})
+
+		middleware.BasicAuth(ctx)
+		middleware.DigestAuth(ctx)
+
+		if len(ctx.Result.Middlewares) != 1 {
+			t.Fatalf("expected a single middleware, got %d", len(ctx.Result.Middlewares))
+		}
+
+		digestAuth := findMiddleware(t, ctx, "digestauth").Spec.DigestAuth
+		if digestAuth == nil {
+			t.Fatalf("expected a DigestAuth middleware")
+		}
+
+		if digestAuth.Secret != "digest-auth" || digestAuth.Realm != "private" {
+			t.Errorf("unexpected digest auth %+v", digestAuth)
+		}
+
+		if len(ctx.Result.Notes) != 1 || !strings.Contains(ctx.Result.Notes[0], "'user:private:hash'") {
+			t.Errorf("expected a htdigest format note, got %v", ctx.Result.Notes)
+		}
+	})
+
+	t.Run("should default to the traefik realm", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.AuthType):   "digest",
+			string(models.AuthSecret): "digest-auth",
+		})
+
+		middleware.DigestAuth(ctx)
+
+		if realm := findMiddleware(t, ctx, "digestauth").Spec.DigestAuth.Realm; realm != "traefik" {
+			t.Errorf("expected realm traefik, got %q", realm)
+		}
+	})
+
+	t.Run("should warn about the auth-map layout", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.AuthType):       "digest",
+			string(models.AuthSecret):     "digest-auth",
+			string(models.AuthSecretType): "auth-map",
+		})
+
+		middleware.DigestAuth(ctx)
+
+		if !hasWarning(ctx, `auth-secret-type "auth-map" is not supported for digest auth`) {
+			t.Errorf("expected an auth-secret-type warning, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should warn when the digest secret holds more than one key", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.AuthType):   "digest",
+			string(models.AuthSecret): "digest-auth",
+		})
+		ctx.Options.Secrets = func(namespace, name string) ([]string, error) {
+			if namespace != "default" || name != "digest-auth" {
+				t.Errorf("unexpected secret lookup %s/%s", namespace, name)
+			}
+
+			return []string{"auth", "users"}, nil
+		}
+
+		middleware.DigestAuth(ctx)
+
+		if !hasWarning(ctx, "digest auth secret default/digest-auth has 2 keys") {
+			t.Errorf("expected a secret keys warning, got %v", ctx.Result.Warnings)
+		}
+
+		if len(ctx.Result.Notes) != 0 {
+			t.Errorf("expected no htdigest format note, got %v", ctx.Result.Notes)
+		}
+	})
+
+	t.Run("should warn when the digest secret cannot be read", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.AuthType):   "digest",
+			string(models.AuthSecret): "digest-auth",
+		})
+		ctx.Options.Secrets = func(_, _ string) ([]string, error) {
+			return nil, errors.New("secrets \"digest-auth\" not found")
+		}
+
+		middleware.DigestAuth(ctx)
+
+		if !hasWarning(ctx, "reading digest auth secret default/digest-auth failed") {
+			t.Errorf("expected a missing secret warning, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should accept a digest secret with a single key", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.AuthType):   "digest",
+			string(models.AuthSecret): "digest-auth",
+		})
+		ctx.Options.Secrets = func(_, _ string) ([]string, error) {
+			return []string{"auth"}, nil
+		}
+
+		middleware.DigestAuth(ctx)
+
+		if len(ctx.Result.Warnings) != 0 || len(ctx.Result.Notes) != 0 {
+			t.Errorf("expected no warnings or notes, got %v %v", ctx.Result.Warnings, ctx.Result.Notes)
+		}
+	})
+}
@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"strings"
+
 	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
 	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
 )
@@ -40,7 +42,7 @@ via entryPoints.<name>.http.maxHeaderBytes in static configuration`
 		warningMessage := "service-upstream=true is default behavior in Traefik"
 
 		ctx.Result.Warnings = append(ctx.Result.Warnings, warningMessage)
-		ctx.ReportIgnored(string(models.ServiceUpstream), warningMessage)
+		ctx.ReportDefault(string(models.ServiceUpstream), warningMessage)
 	}
 
 	if ctx.Annotations[string(models.EnableOpentracing)] == "true" {
@@ -53,18 +55,19 @@ via entryPoints.<name>.http.maxHeaderBytes in static configuration`
 	if ctx.Annotations[string(models.EnableOpentelemetry)] == "true" {
 		warningMessage := "enable-opentelemetry must be configured globally in Traefik static config"
 
-		ctx.Result.Warnings = append(
-			ctx.Result.Warnings,
-			warningMessage+`tracing:
-  otlp:
-    grpc:
-      endpoint: otel-collector:4317`,
-		)
+		// the tracing example is part of the warning regardless of Options.SuggestYAML.
+		msg := warningMessage
+		if snippet, err := staticConfigYAML(staticSetting{"tracing.otlp.grpc.endpoint", "otel-collector:4317"}); err == nil {
+			msg += ", for example:\n" + snippet
+		}
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
 
 		ctx.ReportWarning(string(models.EnableOpentelemetry), warningMessage)
 	}
 
-	if v := ctx.Annotations[string(models.BackendProtocol)]; v != "" {
+	// FCGI backends are reported when the IngressRoute is built, as they cannot be routed.
+	if v := ctx.Annotations[string(models.BackendProtocol)]; v != "" && !strings.EqualFold(v, "FCGI") {
 		warningMessage := "backend-protocol must be applied to IngressRoute service scheme, check for generated ingressroutes.yaml"
 
 		ctx.Result.Warnings = append(ctx.Result.Warnings, warningMessage)
@@ -0,0 +1,22 @@
+package middleware_test
+
+import (
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestExtraAnnotations(t *testing.T) {
+	t.Run("should include the tracing configuration in the opentelemetry warning", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.EnableOpentelemetry): "true",
+		})
+
+		middleware.ExtraAnnotations(ctx)
+
+		if !hasWarning(ctx, "endpoint: otel-collector:4317") {
+			t.Errorf("expected the tracing snippet, got %v", ctx.Result.Warnings)
+		}
+	})
+}
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+	traefik "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+/* ---------------- GLOBAL AUTH ---------------- */
+
+// GlobalAuth handles the below annotations.
+// Annotations:
+//   - "nginx.ingress.kubernetes.io/enable-global-auth"
+//
+// NGINX applies the global-auth-url of the controller ConfigMap to every ingress
+// without its own auth-url, unless the ingress opts out with enable-global-auth.
+// The global auth is taken from Options.GlobalAuthURL.
+func GlobalAuth(ctx configs.Context) {
+	ctx.Log.Debug("running converter GlobalAuth")
+
+	ann := string(models.EnableGlobalAuth)
+
+	val, hasAnnotation := ctx.Annotations[ann]
+
+	address := strings.TrimSpace(ctx.Options.GlobalAuthURL)
+	if address == "" {
+		if hasAnnotation {
+			ctx.ReportDefault(ann, "no global auth url was configured, enable-global-auth has no effect")
+		}
+
+		return
+	}
+
+	if hasAnnotation {
+		enabled, err := strconv.ParseBool(strings.TrimSpace(val))
+		if err != nil {
+			msg := "enable-global-auth is not a boolean, the global auth is applied: " + val
+
+			ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+			ctx.ReportWarning(ann, msg)
+		} else {
+			ctx.ReportConverted(ann)
+
+			if !enabled {
+				ctx.Result.Notes = append(ctx.Result.Notes, "ingress opted out of the global auth with enable-global-auth")
+
+				return
+			}
+		}
+	}
+
+	// the auth-url of the ingress takes precedence over the global auth.
+	if url, ok := ctx.Annotations[string(models.AuthURL)]; ok && strings.TrimSpace(url) != "" {
+		return
+	}
+
+	ctx.Result.Middlewares = append(ctx.Result.Middlewares, &traefik.Middleware{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: traefik.SchemeGroupVersion.String(),
+			Kind:       "Middleware",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mwName(ctx, "global-auth"),
+			Namespace: ctx.Namespace,
+		},
+		Spec: traefik.MiddlewareSpec{
+			ForwardAuth: &traefik.ForwardAuth{
+				Address:            address,
+				TrustForwardHeader: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,66 @@
+package middleware_test
+
+import (
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestGlobalAuth(t *testing.T) {
+	const globalAuthURL = "http://auth.auth.svc.cluster.local/verify"
+
+	t.Run("should apply the global auth to ingresses without an opt-out", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{})
+		ctx.Options.GlobalAuthURL = globalAuthURL
+
+		middleware.GlobalAuth(ctx)
+
+		forwardAuth := findMiddleware(t, ctx, "global-auth").Spec.ForwardAuth
+		if forwardAuth == nil || forwardAuth.Address != globalAuthURL {
+			t.Errorf("unexpected ForwardAuth %+v", forwardAuth)
+		}
+	})
+
+	t.Run("should exclude ingresses with enable-global-auth false", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.EnableGlobalAuth): "false",
+		})
+		ctx.Options.GlobalAuthURL = globalAuthURL
+
+		middleware.GlobalAuth(ctx)
+
+		if len(ctx.Result.Middlewares) != 0 {
+			t.Errorf("expected no middleware, got %d", len(ctx.Result.Middlewares))
+		}
+
+		if len(ctx.Result.Notes) != 1 {
+			t.Errorf("expected an opt-out note, got %v", ctx.Result.Notes)
+		}
+	})
+
+	t.Run("should prefer the auth-url of the ingress", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.AuthURL): "https://auth.example.com/check",
+		})
+		ctx.Options.GlobalAuthURL = globalAuthURL
+
+		middleware.GlobalAuth(ctx)
+
+		if len(ctx.Result.Middlewares) != 0 {
+			t.Errorf("expected no global auth middleware, got %d", len(ctx.Result.Middlewares))
+		}
+	})
+
+	t.Run("should do nothing without a global auth url", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.EnableGlobalAuth): "false",
+		})
+
+		middleware.GlobalAuth(ctx)
+
+		if len(ctx.Result.Middlewares) != 0 {
+			t.Errorf("expected no middleware, got %d", len(ctx.Result.Middlewares))
+		}
+	})
+}
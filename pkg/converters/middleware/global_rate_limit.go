@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/duration"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	traefik "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+)
+
+/* ---------------- GLOBAL RATE LIMIT ---------------- */
+
+// headerVariableRe matches a key made of a single request header variable.
+var headerVariableRe = regexp.MustCompile(`^\$http_([a-zA-Z0-9_]+)$`)
+
+// globalRateLimitRedisNote explains the manual step left on the generated middleware.
+const globalRateLimitRedisNote = "set spec.rateLimit.redis.endpoints (and secret) to the Redis shared by all Traefik replicas, " +
+	"it replaces the memcached instance used by the NGINX global rate limit"
+
+// GlobalRateLimit handles the below annotations.
+// Annotations:
+//   - "nginx.ingress.kubernetes.io/global-rate-limit"
+//   - "nginx.ingress.kubernetes.io/global-rate-limit-window"
+//   - "nginx.ingress.kubernetes.io/global-rate-limit-key"
+func GlobalRateLimit(ctx configs.Context) {
+	ctx.Log.Debug("running converter GlobalRateLimit")
+
+	annLimit := string(models.GlobalRateLimit)
+	annWindow := string(models.GlobalRateLimitWindow)
+	annKey := string(models.GlobalRateLimitKey)
+
+	val, ok := ctx.Annotations[annLimit]
+	if !ok {
+		return
+	}
+
+	limit, err := strconv.Atoi(strings.TrimSpace(val))
+	if err != nil || limit < 1 {
+		msg := fmt.Sprintf("global-rate-limit %q is not a positive integer", val)
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportSkipped(annLimit, msg)
+
+		return
+	}
+
+	// global-rate-limit is ignored by NGINX without a window.
+	window, ok := ctx.Annotations[annWindow]
+	if !ok {
+		msg := "global-rate-limit requires global-rate-limit-window, the limit was not converted"
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportSkipped(annLimit, msg)
+
+		return
+	}
+
+	period, err := duration.Parse(window)
+	if err != nil || period < time.Second {
+		msg := fmt.Sprintf("global-rate-limit-window %q is not a valid window, the limit was not converted", window)
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportSkipped(annWindow, msg)
+		ctx.ReportSkipped(annLimit, msg)
+
+		return
+	}
+
+	// NGINX counts requests in a sliding window without burst, allowing the full
+	// limit at once is the closest token bucket equivalent.
+	mw := newRateLimitMiddleware(ctx, "global-ratelimit", limit, duration.Format(period), limit)
+	mw.Annotations = map[string]string{
+		PlaceholderAnnotation: "manual configuration required: " + globalRateLimitRedisNote,
+	}
+	mw.Spec.RateLimit.Redis = &traefik.Redis{}
+
+	if key, ok := ctx.Annotations[annKey]; ok {
+		criterion, converted := globalRateLimitSourceCriterion(key)
+		mw.Spec.RateLimit.SourceCriterion = criterion
+
+		if converted {
+			ctx.ReportConverted(annKey)
+		} else {
+			msg := fmt.Sprintf("global-rate-limit-key %q cannot be expressed as a Traefik sourceCriterion; "+
+				"requests are grouped by client IP instead", key)
+
+			ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+			ctx.ReportWarning(annKey, msg)
+		}
+	}
+
+	ctx.Result.Middlewares = append(ctx.Result.Middlewares, mw)
+
+	msg := fmt.Sprintf(
+		"global-rate-limit converted to a Redis backed RateLimit with average %d and period %s; %s",
+		limit, duration.Format(period), globalRateLimitRedisNote,
+	)
+
+	ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+	ctx.ReportWarning(annLimit, msg)
+	ctx.ReportConverted(annWindow)
+}
+
+// globalRateLimitSourceCriterion maps the NGINX key of the global rate limit to a
+// Traefik sourceCriterion, reporting whether the key could be mapped.
+func globalRateLimitSourceCriterion(key string) (*dynamic.SourceCriterion, bool) {
+	key = strings.TrimSpace(key)
+
+	switch {
+	case key == "$remote_addr" || key == "$binary_remote_addr":
+		return nil, true
+
+	case key == "$host" || key == "$http_host":
+		return &dynamic.SourceCriterion{RequestHost: true}, true
+
+	case headerVariableRe.MatchString(key):
+		return &dynamic.SourceCriterion{
+			RequestHeaderName: strings.ReplaceAll(headerVariableRe.FindStringSubmatch(key)[1], "_", "-"),
+		}, true
+
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,73 @@
+package middleware_test
+
+import (
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestGlobalRateLimit(t *testing.T) {
+	t.Run("should generate a redis backed rate limit with the computed average and period", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.GlobalRateLimit):       "100",
+			string(models.GlobalRateLimitWindow): "1m",
+			string(models.GlobalRateLimitKey):    "$http_x_api_key",
+		})
+
+		middleware.GlobalRateLimit(ctx)
+
+		mw := findMiddleware(t, ctx, "global-ratelimit")
+
+		rateLimit := mw.Spec.RateLimit
+		if rateLimit == nil || rateLimit.Redis == nil {
+			t.Fatalf("expected a redis backed RateLimit middleware")
+		}
+
+		if *rateLimit.Average != 100 || rateLimit.Period.String() != "60s" {
+			t.Errorf("unexpected average %d and period %s", *rateLimit.Average, rateLimit.Period.String())
+		}
+
+		if rateLimit.SourceCriterion == nil || rateLimit.SourceCriterion.RequestHeaderName != "x-api-key" {
+			t.Errorf("expected requests to be grouped by the x-api-key header, got %+v", rateLimit.SourceCriterion)
+		}
+
+		if mw.Annotations[middleware.PlaceholderAnnotation] == "" {
+			t.Errorf("expected a placeholder annotation")
+		}
+
+		if !hasWarning(ctx, "average 100 and period 60s") {
+			t.Errorf("expected the computed limit in the warnings, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should fall back to the client IP for keys that cannot be mapped", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.GlobalRateLimit):       "10",
+			string(models.GlobalRateLimitWindow): "10s",
+			string(models.GlobalRateLimitKey):    "$remote_addr$uri",
+		})
+
+		middleware.GlobalRateLimit(ctx)
+
+		if findMiddleware(t, ctx, "global-ratelimit").Spec.RateLimit.SourceCriterion != nil {
+			t.Errorf("expected no sourceCriterion")
+		}
+
+		if !hasWarning(ctx, "cannot be expressed as a Traefik sourceCriterion") {
+			t.Errorf("expected a key warning, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should skip the limit without a window", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.GlobalRateLimit): "10",
+		})
+
+		middleware.GlobalRateLimit(ctx)
+
+		if len(ctx.Result.Middlewares) != 0 {
+			t.Errorf("expected no middleware, got %d", len(ctx.Result.Middlewares))
+		}
+	})
+}
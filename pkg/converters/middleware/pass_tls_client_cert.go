@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	traefik "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+/* ---------------- PASS TLS CLIENT CERT ---------------- */
+
+// passTLSClientCertHeadersWarning explains the header names differing from NGINX.
+const passTLSClientCertHeadersWarning = "auth-tls-pass-certificate-to-upstream converted to PassTLSClientCert; " +
+	"the backend receives X-Forwarded-Tls-Client-Cert and X-Forwarded-Tls-Client-Cert-Info " +
+	"instead of the NGINX ssl-client-cert, ssl-client-subject-dn and ssl-client-issuer-dn headers"
+
+// PassTLSClientCert handles the below annotations.
+// Annotations:
+//   - "nginx.ingress.kubernetes.io/auth-tls-pass-certificate-to-upstream"
+func PassTLSClientCert(ctx configs.Context) {
+	ctx.Log.Debug("running converter PassTLSClientCert")
+
+	ann := string(models.AuthTLSPassCertificate)
+
+	val, ok := ctx.Annotations[ann]
+	if !ok {
+		return
+	}
+
+	if strings.TrimSpace(strings.ToLower(val)) != "true" {
+		ctx.ReportDefault(ann, "auth-tls-pass-certificate-to-upstream is not enabled")
+
+		return
+	}
+
+	verify := ctx.Annotations[string(models.AuthTLSVerifyClient)]
+	if verify == "" || verify == "off" || verify == "false" {
+		msg := "auth-tls-pass-certificate-to-upstream requires auth-tls-verify-client, no client certificate is requested"
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportSkipped(ann, msg)
+
+		return
+	}
+
+	// NGINX passes the subject and issuer DN of the verified certificate along
+	// with the PEM, the info header carries the same details.
+	ctx.Result.Middlewares = append(ctx.Result.Middlewares, &traefik.Middleware{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: traefik.SchemeGroupVersion.String(),
+			Kind:       "Middleware",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mwName(ctx, "pass-tls-client-cert"),
+			Namespace: ctx.Namespace,
+		},
+		Spec: traefik.MiddlewareSpec{
+			PassTLSClientCert: &dynamic.PassTLSClientCert{
+				PEM: true,
+				Info: &dynamic.TLSClientCertificateInfo{
+					Subject: &dynamic.TLSClientCertificateSubjectDNInfo{
+						Country:            true,
+						Province:           true,
+						Locality:           true,
+						Organization:       true,
+						OrganizationalUnit: true,
+						CommonName:         true,
+						SerialNumber:       true,
+						DomainComponent:    true,
+					},
+					Issuer: &dynamic.TLSClientCertificateIssuerDNInfo{
+						Country:         true,
+						Province:        true,
+						Locality:        true,
+						Organization:    true,
+						CommonName:      true,
+						SerialNumber:    true,
+						DomainComponent: true,
+					},
+				},
+			},
+		},
+	})
+
+	ctx.Result.Warnings = append(ctx.Result.Warnings, passTLSClientCertHeadersWarning)
+	ctx.ReportWarning(ann, passTLSClientCertHeadersWarning)
+}
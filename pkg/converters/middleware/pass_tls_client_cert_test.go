@@ -0,0 +1,44 @@
+package middleware_test
+
+import (
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestPassTLSClientCert(t *testing.T) {
+	t.Run("should pass the PEM and DN details of the client certificate", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.AuthTLSVerifyClient):    "on",
+			string(models.AuthTLSPassCertificate): "true",
+		})
+
+		middleware.PassTLSClientCert(ctx)
+
+		passCert := findMiddleware(t, ctx, "pass-tls-client-cert").Spec.PassTLSClientCert
+		if passCert == nil || !passCert.PEM {
+			t.Fatalf("expected a PassTLSClientCert middleware with pem, got %+v", passCert)
+		}
+
+		if passCert.Info == nil || !passCert.Info.Subject.CommonName || !passCert.Info.Issuer.CommonName {
+			t.Errorf("expected subject and issuer details, got %+v", passCert.Info)
+		}
+
+		if !hasWarning(ctx, "X-Forwarded-Tls-Client-Cert") {
+			t.Errorf("expected a header name warning, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should skip without client certificate verification", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.AuthTLSPassCertificate): "true",
+		})
+
+		middleware.PassTLSClientCert(ctx)
+
+		if len(ctx.Result.Middlewares) != 0 {
+			t.Errorf("expected no middleware, got %d", len(ctx.Result.Middlewares))
+		}
+	})
+}
@@ -0,0 +1,141 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/errors"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	traefik "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+/* ---------------- PERMANENT REDIRECT ---------------- */
+
+// PermanentRedirect handles the below annotations.
+// Annotations:
+//   - "nginx.ingress.kubernetes.io/permanent-redirect"
+//   - "nginx.ingress.kubernetes.io/permanent-redirect-code"
+func PermanentRedirect(ctx configs.Context) {
+	ctx.Log.Debug("running converter PermanentRedirect")
+
+	annRedirect := string(models.PermanentRedirect)
+	annRedirectCode := string(models.PermanentRedirectCode)
+
+	target, ok := ctx.Annotations[annRedirect]
+	if !ok || strings.TrimSpace(target) == "" {
+		return
+	}
+
+	// NGINX prefers a valid temporal-redirect over permanent-redirect.
+	if temporal, ok := ctx.Annotations[string(models.TemporalRedirect)]; ok && validateRedirectTarget(temporal) == nil {
+		ctx.ReportIgnored(annRedirect, "permanent-redirect is overridden by temporal-redirect")
+
+		return
+	}
+
+	if err := validateRedirectTarget(target); err != nil {
+		msg := "permanent-redirect " + err.Error()
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportSkipped(annRedirect, msg)
+
+		return
+	}
+
+	code, hasCode := http.StatusMovedPermanently, false
+
+	if val, ok := ctx.Annotations[annRedirectCode]; ok {
+		parsed, err := strconv.Atoi(strings.TrimSpace(val))
+		if err != nil {
+			warningMessage := fmt.Sprintf("permanent-redirect-code %q is not a valid status code; defaulted to 301", val)
+
+			ctx.Result.Warnings = append(ctx.Result.Warnings, warningMessage)
+			ctx.ReportWarning(annRedirectCode, warningMessage)
+		} else {
+			code, hasCode = parsed, true
+		}
+	}
+
+	// Traefik's RedirectRegex only exposes a boolean: permanent redirects answer
+	// 301 (GET) or 308 (other methods), temporary ones 302 or 307.
+	permanent := true
+
+	switch code {
+	case http.StatusMovedPermanently:
+		if hasCode {
+			ctx.ReportConverted(annRedirectCode)
+		}
+
+	case http.StatusPermanentRedirect:
+		warningMessage := "permanent-redirect-code 308 mapped to a permanent RedirectRegex; Traefik preserves the method " +
+			"with a 308 for non-GET requests but answers GET requests with a 301"
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, warningMessage)
+		ctx.ReportWarning(annRedirectCode, warningMessage)
+
+	case http.StatusTemporaryRedirect:
+		permanent = false
+
+		warningMessage := "permanent-redirect-code 307 cannot be produced by Traefik RedirectRegex for all methods; " +
+			"a temporary redirect was generated which answers GET requests with a 302, " +
+			"handle the redirect in the backend if a strict 307 is required"
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, warningMessage)
+		ctx.ReportWarning(annRedirectCode, warningMessage)
+
+	default:
+		warningMessage := fmt.Sprintf(
+			"permanent-redirect-code %d cannot be expressed with Traefik RedirectRegex; a permanent (301) redirect was generated", code,
+		)
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, warningMessage)
+		ctx.ReportWarning(annRedirectCode, warningMessage)
+	}
+
+	ctx.Result.Middlewares = append(ctx.Result.Middlewares,
+		newRedirectRegexMiddleware(ctx, "permanent-redirect", &dynamic.RedirectRegex{
+			Regex:       "^.*$",
+			Replacement: strings.TrimSpace(target),
+			Permanent:   permanent,
+		}),
+	)
+
+	ctx.ReportConverted(annRedirect)
+}
+
+// validateRedirectTarget checks that a redirect annotation holds an absolute
+// http(s) URL, NGINX ignores redirect annotations with any other value.
+func validateRedirectTarget(target string) error {
+	parsed, err := url.Parse(strings.TrimSpace(target))
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return &errors.ConverterError{Message: fmt.Sprintf("target %q is not an absolute http(s) URL", target)}
+	}
+
+	return nil
+}
+
+func newRedirectRegexMiddleware(
+	ctx configs.Context,
+	name string,
+	redirect *dynamic.RedirectRegex,
+) *traefik.Middleware {
+	return &traefik.Middleware{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: traefik.SchemeGroupVersion.String(),
+			Kind:       "Middleware",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mwName(ctx, name),
+			Namespace: ctx.Namespace,
+		},
+		Spec: traefik.MiddlewareSpec{
+			RedirectRegex: redirect,
+		},
+	}
+}
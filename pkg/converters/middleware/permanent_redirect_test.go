@@ -0,0 +1,102 @@
+package middleware_test
+
+import (
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestPermanentRedirect(t *testing.T) {
+	tests := []struct {
+		name          string
+		code          string
+		wantPermanent bool
+		wantWarning   string
+	}{
+		{
+			name:          "should emit a permanent redirect for 301",
+			code:          "301",
+			wantPermanent: true,
+		},
+		{
+			name:          "should emit a permanent redirect and note method preservation for 308",
+			code:          "308",
+			wantPermanent: true,
+			wantWarning:   "preserves the method",
+		},
+		{
+			name:          "should emit a temporary redirect and warn for 307",
+			code:          "307",
+			wantPermanent: false,
+			wantWarning:   "cannot be produced by Traefik RedirectRegex",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := newTestContext(map[string]string{
+				string(models.PermanentRedirect):     "https://example.com/new",
+				string(models.PermanentRedirectCode): tt.code,
+			})
+
+			middleware.PermanentRedirect(ctx)
+
+			redirect := findMiddleware(t, ctx, "permanent-redirect").Spec.RedirectRegex
+			if redirect == nil {
+				t.Fatalf("expected a RedirectRegex middleware")
+			}
+
+			if redirect.Replacement != "https://example.com/new" {
+				t.Errorf("unexpected replacement %q", redirect.Replacement)
+			}
+
+			if redirect.Permanent != tt.wantPermanent {
+				t.Errorf("expected permanent=%t, got %t", tt.wantPermanent, redirect.Permanent)
+			}
+
+			if tt.wantWarning == "" && len(ctx.Result.Warnings) != 0 {
+				t.Errorf("expected no warnings, got %v", ctx.Result.Warnings)
+			}
+
+			if tt.wantWarning != "" && !hasWarning(ctx, tt.wantWarning) {
+				t.Errorf("expected warning containing %q, got %v", tt.wantWarning, ctx.Result.Warnings)
+			}
+		})
+	}
+}
+
+func TestPermanentRedirect_validation(t *testing.T) {
+	t.Run("should skip a target that is not an absolute URL", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.PermanentRedirect): "/new",
+		})
+
+		middleware.PermanentRedirect(ctx)
+
+		if len(ctx.Result.Middlewares) != 0 {
+			t.Errorf("expected no middleware, got %d", len(ctx.Result.Middlewares))
+		}
+
+		if !hasWarning(ctx, `permanent-redirect target "/new" is not an absolute http(s) URL`) {
+			t.Errorf("expected a target warning, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should default to a permanent redirect for an invalid code", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.PermanentRedirect):     "https://example.com/new",
+			string(models.PermanentRedirectCode): "moved",
+		})
+
+		middleware.PermanentRedirect(ctx)
+
+		if !findMiddleware(t, ctx, "permanent-redirect").Spec.RedirectRegex.Permanent {
+			t.Errorf("expected a permanent redirect")
+		}
+
+		if !hasWarning(ctx, "defaulted to 301") {
+			t.Errorf("expected a code warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
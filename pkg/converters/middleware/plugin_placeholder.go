@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"encoding/json"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	traefik "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+/* ---------------- Plugin placeholders ---------------- */
+
+// emitPluginPlaceholders reports whether sub_filter directives should be
+// scaffolded as a plugin middleware instead of only being warned about.
+func emitPluginPlaceholders(ctx configs.Context) bool {
+	return ctx.Options.PluginPlaceholders && !ctx.Options.DisablePlugins
+}
+
+// newPluginPlaceholder builds a plugin middleware skeleton for the given plugin.
+// The config is a best-effort starting point and the middleware is annotated
+// with the note explaining what needs to be configured manually.
+func newPluginPlaceholder(
+	ctx configs.Context,
+	suffix, plugin string,
+	config map[string]any,
+	note string,
+) (*traefik.Middleware, error) {
+	raw, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &traefik.Middleware{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: traefik.SchemeGroupVersion.String(),
+			Kind:       "Middleware",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mwName(ctx, suffix),
+			Namespace: ctx.Namespace,
+			Annotations: map[string]string{
+				PlaceholderAnnotation: "manual configuration required: " + note,
+			},
+		},
+		Spec: traefik.MiddlewareSpec{
+			Plugin: map[string]apiextv1.JSON{
+				plugin: {Raw: raw},
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+/* ---------------- PRESERVE TRAILING SLASH ---------------- */
+
+// PreserveTrailingSlash handles the below annotations.
+// Annotations:
+//   - "nginx.ingress.kubernetes.io/preserve-trailing-slash"
+//
+// NGINX strips the trailing slash of the URI on the ssl-redirect unless the
+// annotation is set. The generated Traefik redirects always keep the request URI
+// as is, including the trailing slash.
+func PreserveTrailingSlash(ctx configs.Context) {
+	ctx.Log.Debug("running converter PreserveTrailingSlash")
+
+	ann := string(models.PreserveTrailingSlash)
+
+	val, ok := ctx.Annotations[ann]
+	if !ok {
+		return
+	}
+
+	preserve, err := strconv.ParseBool(strings.TrimSpace(val))
+	if err != nil {
+		msg := "preserve-trailing-slash is not a boolean: " + val
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportSkipped(ann, msg)
+
+		return
+	}
+
+	if preserve {
+		ctx.ReportConverted(ann)
+
+		return
+	}
+
+	// the trailing slash is only stripped on the ssl-redirect of NGINX.
+	if ctx.Annotations[string(models.SSLRedirect)] != "true" && ctx.Annotations[string(models.ForceSSLRedirect)] != "true" {
+		ctx.ReportDefault(ann, "no https redirect is generated, preserve-trailing-slash has no effect")
+
+		return
+	}
+
+	msg := "preserve-trailing-slash=false cannot be reproduced, Traefik redirects keep the trailing slash of the request"
+
+	ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+	ctx.ReportWarning(ann, msg)
+}
@@ -0,0 +1,59 @@
+package middleware_test
+
+import (
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestPreserveTrailingSlash(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantWarning string
+	}{
+		{
+			name: "should keep the trailing slash on the ssl redirect",
+			annotations: map[string]string{
+				string(models.SSLRedirect):           "true",
+				string(models.PreserveTrailingSlash): "true",
+			},
+		},
+		{
+			name: "should not warn when combined with a static rewrite-target",
+			annotations: map[string]string{
+				string(models.RewriteTarget):         "/app",
+				string(models.PreserveTrailingSlash): "true",
+			},
+		},
+		{
+			name: "should warn that trailing slashes cannot be stripped on the ssl redirect",
+			annotations: map[string]string{
+				string(models.SSLRedirect):           "true",
+				string(models.PreserveTrailingSlash): "false",
+			},
+			wantWarning: "Traefik redirects keep the trailing slash",
+		},
+		{
+			name:        "should not warn without an ssl redirect",
+			annotations: map[string]string{string(models.PreserveTrailingSlash): "false"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := newTestContext(tt.annotations)
+
+			middleware.PreserveTrailingSlash(ctx)
+
+			if tt.wantWarning == "" && len(ctx.Result.Warnings) != 0 {
+				t.Errorf("expected no warnings, got %v", ctx.Result.Warnings)
+			}
+
+			if tt.wantWarning != "" && !hasWarning(ctx, tt.wantWarning) {
+				t.Errorf("expected warning containing %q, got %v", tt.wantWarning, ctx.Result.Warnings)
+			}
+		})
+	}
+}
@@ -35,7 +35,7 @@ func ProxyBuffering(ctx configs.Context) {
 
 		ctx.Result.Warnings = append(ctx.Result.Warnings, warningMessage)
 
-		ctx.ReportIgnored(ann, warningMessage)
+		ctx.ReportDefault(ann, warningMessage)
 	default:
 		warningMessage := fmt.Sprintf(
 			"nginx.ingress.kubernetes.io/proxy-buffering has unknown value %q and was ignored", val)
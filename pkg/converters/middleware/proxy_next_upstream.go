@@ -0,0 +1,199 @@
+package middleware
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/duration"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+	traefik "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+/* ---------------- PROXY NEXT UPSTREAM ---------------- */
+
+// defaultNextUpstreamTries is the ingress-nginx default of proxy-next-upstream-tries.
+const defaultNextUpstreamTries = 3
+
+// networkNextUpstreamConditions are the proxy-next-upstream conditions covered by the
+// Traefik Retry middleware, which only retries when the backend could not be reached.
+var networkNextUpstreamConditions = []string{"error", "timeout"}
+
+// ProxyNextUpstream handles the below annotations.
+// Annotations:
+//   - "nginx.ingress.kubernetes.io/proxy-next-upstream"
+//   - "nginx.ingress.kubernetes.io/proxy-next-upstream-tries"
+//   - "nginx.ingress.kubernetes.io/proxy-next-upstream-timeout"
+func ProxyNextUpstream(ctx configs.Context) {
+	ctx.Log.Debug("running converter ProxyNextUpstream")
+
+	annNextUpstream := string(models.ProxyNextUpstream)
+	annTries := string(models.ProxyNextUpstreamTries)
+	annTimeout := string(models.ProxyNextUpstreamTimeout)
+
+	conditions, hasConditions := ctx.Annotations[annNextUpstream]
+	tries, hasTries := ctx.Annotations[annTries]
+	timeout, hasTimeout := ctx.Annotations[annTimeout]
+
+	if !hasConditions && !hasTries && !hasTimeout {
+		return
+	}
+
+	fields := strings.Fields(conditions)
+
+	if slices.Contains(fields, "off") {
+		ctx.ReportDefault(annNextUpstream, "retries are disabled, Traefik does not retry without a Retry middleware")
+
+		if hasTries {
+			ctx.ReportDefault(annTries, "proxy-next-upstream is off")
+		}
+
+		if hasTimeout {
+			ctx.ReportDefault(annTimeout, "proxy-next-upstream is off")
+		}
+
+		return
+	}
+
+	retry := &traefik.Retry{Attempts: nextUpstreamTries(ctx, tries, hasTries)}
+
+	if hasTimeout {
+		nextUpstreamTimeout(ctx, retry, timeout)
+	}
+
+	ctx.Result.Middlewares = append(ctx.Result.Middlewares, &traefik.Middleware{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: traefik.SchemeGroupVersion.String(),
+			Kind:       "Middleware",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mwName(ctx, "retry"),
+			Namespace: ctx.Namespace,
+		},
+		Spec: traefik.MiddlewareSpec{
+			Retry: retry,
+		},
+	})
+
+	if hasConditions {
+		reportNextUpstreamConditions(ctx, fields)
+	}
+}
+
+// nextUpstreamTries returns the Retry attempts for proxy-next-upstream-tries. Like
+// NGINX the attempts include the first request.
+func nextUpstreamTries(ctx configs.Context, tries string, hasTries bool) int {
+	ann := string(models.ProxyNextUpstreamTries)
+
+	if !hasTries {
+		return defaultNextUpstreamTries
+	}
+
+	attempts, err := strconv.Atoi(strings.TrimSpace(tries))
+
+	switch {
+	case err != nil || attempts < 0:
+		msg := fmt.Sprintf("proxy-next-upstream-tries has an invalid value %q, using %d attempts", tries, defaultNextUpstreamTries)
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportWarning(ann, msg)
+
+		return defaultNextUpstreamTries
+	case attempts == 0:
+		msg := fmt.Sprintf("proxy-next-upstream-tries 0 (unlimited) cannot be expressed in Traefik, using %d attempts",
+			defaultNextUpstreamTries)
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportWarning(ann, msg)
+
+		return defaultNextUpstreamTries
+	}
+
+	ctx.ReportConverted(ann)
+
+	return attempts
+}
+
+// nextUpstreamTimeout maps proxy-next-upstream-timeout onto the Retry initialInterval.
+// Traefik has no deadline for all attempts together, the backoff is spread so that
+// the waits between the attempts stay within the NGINX timeout.
+func nextUpstreamTimeout(ctx configs.Context, retry *traefik.Retry, timeout string) {
+	ann := string(models.ProxyNextUpstreamTimeout)
+
+	parsed, err := duration.Parse(timeout)
+	if err != nil {
+		msg := fmt.Sprintf("proxy-next-upstream-timeout has an invalid value %q and was not converted", timeout)
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportSkipped(ann, msg)
+
+		return
+	}
+
+	if parsed == 0 {
+		ctx.ReportDefault(ann, "no retry timeout is the Traefik default")
+
+		return
+	}
+
+	// the backoff waits at most twice the initialInterval between two attempts.
+	interval := (parsed / time.Duration(2*max(retry.Attempts, 1))).Truncate(time.Millisecond)
+	if interval > 0 {
+		retry.InitialInterval = intstr.FromString(duration.Format(interval))
+	}
+
+	msg := fmt.Sprintf(
+		"proxy-next-upstream-timeout %s has no Traefik equivalent, Traefik does not limit the total retry time; "+
+			"the Retry initialInterval was set to %s to keep the waits between attempts within it",
+		duration.Format(parsed), retry.InitialInterval.String(),
+	)
+
+	ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+	ctx.ReportWarning(ann, msg)
+}
+
+// reportNextUpstreamConditions warns about the proxy-next-upstream conditions that
+// the Retry middleware does not honour.
+func reportNextUpstreamConditions(ctx configs.Context, conditions []string) {
+	ann := string(models.ProxyNextUpstream)
+
+	unsupported := make([]string, 0)
+
+	for _, condition := range conditions {
+		if !slices.Contains(networkNextUpstreamConditions, condition) && condition != "non_idempotent" {
+			unsupported = append(unsupported, condition)
+		}
+	}
+
+	warnings := make([]string, 0)
+
+	if len(unsupported) > 0 {
+		warnings = append(warnings, fmt.Sprintf(
+			"proxy-next-upstream conditions %s are not retried by Traefik, the Retry middleware only retries network errors "+
+				"and stops as soon as the backend answers, regardless of the response status",
+			strings.Join(unsupported, ", "),
+		))
+	}
+
+	if !slices.Contains(conditions, "non_idempotent") {
+		warnings = append(warnings,
+			"proxy-next-upstream without non_idempotent does not retry POST, LOCK and PATCH requests in NGINX, "+
+				"the Traefik Retry middleware retries every method")
+	}
+
+	if len(warnings) == 0 {
+		ctx.ReportConverted(ann)
+
+		return
+	}
+
+	msg := strings.Join(warnings, "; ")
+
+	ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+	ctx.ReportWarning(ann, msg)
+}
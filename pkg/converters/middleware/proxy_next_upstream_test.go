@@ -0,0 +1,70 @@
+package middleware_test
+
+import (
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestProxyNextUpstream(t *testing.T) {
+	t.Run("should convert the tries and timeout into a Retry middleware", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ProxyNextUpstream):        "error timeout non_idempotent",
+			string(models.ProxyNextUpstreamTries):   "4",
+			string(models.ProxyNextUpstreamTimeout): "8",
+		})
+
+		middleware.ProxyNextUpstream(ctx)
+
+		retry := findMiddleware(t, ctx, "retry").Spec.Retry
+		if retry == nil {
+			t.Fatal("expected a Retry middleware")
+		}
+
+		if retry.Attempts != 4 {
+			t.Errorf("expected 4 attempts, got %d", retry.Attempts)
+		}
+
+		if retry.InitialInterval.String() != "1s" {
+			t.Errorf("expected initialInterval 1s, got %s", retry.InitialInterval.String())
+		}
+
+		if hasWarning(ctx, "are not retried by Traefik") {
+			t.Errorf("expected no condition warning, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should warn about status code conditions", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ProxyNextUpstream): "error http_502 http_503",
+		})
+
+		middleware.ProxyNextUpstream(ctx)
+
+		if findMiddleware(t, ctx, "retry").Spec.Retry.Attempts != 3 {
+			t.Errorf("expected the default of 3 attempts")
+		}
+
+		if !hasWarning(ctx, "http_502, http_503 are not retried by Traefik") {
+			t.Errorf("expected condition warning, got %v", ctx.Result.Warnings)
+		}
+
+		if !hasWarning(ctx, "retries every method") {
+			t.Errorf("expected non_idempotent warning, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should not retry when proxy-next-upstream is off", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ProxyNextUpstream):      "off",
+			string(models.ProxyNextUpstreamTries): "3",
+		})
+
+		middleware.ProxyNextUpstream(ctx)
+
+		if len(ctx.Result.Middlewares) != 0 {
+			t.Errorf("expected no middlewares, got %d", len(ctx.Result.Middlewares))
+		}
+	})
+}
@@ -1,17 +1,29 @@
 package middleware
 
 import (
+	"regexp"
+	"strings"
+
 	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
 	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
 )
 
 /* ---------------- PROXY REDIRECT ---------------- */
 
+const (
+	rewriteResponseHeadersModule  = "github.com/jamesmcroft/traefik-plugin-rewrite-response-headers"
+	rewriteResponseHeadersVersion = "v1.1.2"
+)
+
 // ProxyRedirect handles the below annotations.
 // Annotations:
 //   - "nginx.ingress.kubernetes.io/proxy-redirect-from"
 //   - "nginx.ingress.kubernetes.io/proxy-redirect-to"
+//
+// The prefix of the upstream Location header is rewritten with the rewrite-response-headers plugin.
 func ProxyRedirect(ctx configs.Context) error {
+	ctx.Log.Debug("running converter ProxyRedirect")
+
 	annRedirectFrom := string(models.ProxyRedirectFrom)
 	annRedirectTo := string(models.ProxyRedirectTo)
 
@@ -22,13 +34,56 @@ func ProxyRedirect(ctx configs.Context) error {
 		return nil
 	}
 
-	mw, err := newRewriteResponseHeadersMiddleware(ctx, "Set-Cookie", redirectFrom, redirectTo, "proxy-redirect")
+	redirectFrom = strings.TrimSpace(redirectFrom)
+	redirectTo = strings.TrimSpace(redirectTo)
+
+	skip := func(msg string) {
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportSkipped(annRedirectFrom, msg)
+		ctx.ReportSkipped(annRedirectTo, msg)
+	}
+
+	switch {
+	case redirectFrom == "off":
+		ctx.ReportDefault(annRedirectFrom, "Traefik does not rewrite upstream Location headers")
+		ctx.ReportDefault(annRedirectTo, "proxy-redirect-from is off")
+
+		return nil
+	case redirectFrom == "default":
+		skip("proxy-redirect-from=default depends on the NGINX proxy_pass URL and cannot be converted, " +
+			"set an explicit proxy-redirect-from and proxy-redirect-to")
+
+		return nil
+	case redirectFrom == "" || redirectTo == "":
+		skip("proxy-redirect-from and proxy-redirect-to must both be set to rewrite the upstream Location header")
+
+		return nil
+	case ctx.Options.DisablePlugins:
+		skip("proxy-redirect-from/proxy-redirect-to have no native Traefik equivalent; " +
+			"requires a response header rewrite plugin or backend change")
+
+		return nil
+	}
+
+	regex := "^" + regexp.QuoteMeta(redirectFrom) + "(.*)$"
+	replacement := strings.ReplaceAll(redirectTo, "$", "$$") + "${1}"
+
+	mw, err := newRewriteResponseHeadersMiddleware(ctx, "Location", regex, replacement, "proxy-redirect")
 	if err != nil {
 		return err
 	}
 
 	ctx.Result.Middlewares = append(ctx.Result.Middlewares, mw)
 
+	msg := staticConfigGuidance(ctx,
+		"proxy-redirect-from/proxy-redirect-to were converted to a rewriteResponseHeaders plugin middleware, "+
+			"the plugin "+rewriteResponseHeadersModule+" must be enabled in the Traefik static configuration",
+		staticSetting{"experimental.plugins.rewriteResponseHeaders.moduleName", rewriteResponseHeadersModule},
+		staticSetting{"experimental.plugins.rewriteResponseHeaders.version", rewriteResponseHeadersVersion},
+	)
+
+	ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+
 	ctx.ReportConverted(annRedirectFrom)
 
 	ctx.ReportConverted(annRedirectTo)
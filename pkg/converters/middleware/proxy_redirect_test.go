@@ -0,0 +1,82 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestProxyRedirect(t *testing.T) {
+	t.Run("should rewrite the Location header prefix", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ProxyRedirectFrom): "http://backend.local/",
+			string(models.ProxyRedirectTo):   "https://example.com/app/",
+		})
+
+		if err := middleware.ProxyRedirect(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		mw := findMiddleware(t, ctx, "proxy-redirect")
+
+		var config struct {
+			Rewrites []struct {
+				Header      string `json:"header"`
+				Regex       string `json:"regex"`
+				Replacement string `json:"replacement"`
+			} `json:"rewrites"`
+		}
+
+		if err := json.Unmarshal(mw.Spec.Plugin["rewriteResponseHeaders"].Raw, &config); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(config.Rewrites) != 1 {
+			t.Fatalf("expected one rewrite, got %d", len(config.Rewrites))
+		}
+
+		rewrite := config.Rewrites[0]
+
+		if rewrite.Header != "Location" {
+			t.Errorf("expected Location header, got %q", rewrite.Header)
+		}
+
+		if rewrite.Regex != `^http://backend\.local/(.*)$` || rewrite.Replacement != "https://example.com/app/${1}" {
+			t.Errorf("unexpected rewrite %q -> %q", rewrite.Regex, rewrite.Replacement)
+		}
+
+		if !hasWarning(ctx, "must be enabled in the Traefik static configuration") {
+			t.Errorf("expected plugin warning, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should ignore proxy-redirect-from off", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{string(models.ProxyRedirectFrom): "off"})
+
+		if err := middleware.ProxyRedirect(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.Middlewares) != 0 || len(ctx.Result.Warnings) != 0 {
+			t.Errorf("expected nothing, got %v %v", ctx.Result.Middlewares, ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should skip when plugins are disabled", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ProxyRedirectFrom): "/",
+			string(models.ProxyRedirectTo):   "/app/",
+		})
+		ctx.Options.DisablePlugins = true
+
+		if err := middleware.ProxyRedirect(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(ctx.Result.Middlewares) != 0 || !hasWarning(ctx, "no native Traefik equivalent") {
+			t.Errorf("expected a skip warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
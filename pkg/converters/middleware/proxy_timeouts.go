@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/duration"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/transport"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+/* ---------------- PROXY TIMEOUTS ---------------- */
+
+// ProxyTimeouts handles the below annotations.
+// Annotations:
+//   - "nginx.ingress.kubernetes.io/proxy-connect-timeout"
+//   - "nginx.ingress.kubernetes.io/proxy-read-timeout"
+//   - "nginx.ingress.kubernetes.io/proxy-send-timeout"
+func ProxyTimeouts(ctx configs.Context) {
+	ctx.Log.Debug("running converter ProxyTimeouts")
+
+	for _, annotation := range []models.Annotation{
+		models.ProxyConnectTimeout,
+		models.ProxyReadTimeout,
+		models.ProxySendTimeout,
+	} {
+		ann := string(annotation)
+
+		val, ok := ctx.Annotations[ann]
+		if !ok {
+			continue
+		}
+
+		msg, err := applyTimeout(ctx, strings.TrimPrefix(ann, "nginx.ingress.kubernetes.io/"), val)
+
+		switch {
+		case err != nil:
+			ctx.Result.Warnings = append(ctx.Result.Warnings, err.Error())
+			ctx.ReportSkipped(ann, err.Error())
+		case msg != "":
+			ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+			ctx.ReportWarning(ann, msg)
+		default:
+			ctx.ReportConverted(ann)
+		}
+	}
+}
+
+// applyTimeout sets the NGINX upstream timeout `name` on the forwarding timeouts of
+// the ServersTransport of the ingress. The name may be given as annotation or as
+// snippet directive. It returns a warning when Traefik applies the timeout
+// differently, and an error when the timeout cannot be mapped.
+func applyTimeout(ctx configs.Context, name, val string) (string, error) {
+	directive := strings.ReplaceAll(name, "-", "_")
+
+	if directive == "proxy_send_timeout" {
+		return "", &errors.ConverterError{
+			Message: name + " has no Traefik equivalent; Traefik does not limit the time to send a request to the backend",
+		}
+	}
+
+	parsed, err := duration.Parse(val)
+	if err != nil {
+		return "", &errors.ConverterError{Message: fmt.Sprintf(
+			"%s has an invalid value %q (expected e.g. 60, 60s, 500ms or 1m) and was not converted", name, val,
+		)}
+	}
+
+	timeout := intstr.FromString(duration.Format(parsed))
+
+	if directive == "proxy_connect_timeout" {
+		transport.ForwardingTimeouts(ctx).DialTimeout = &timeout
+
+		return "", nil
+	}
+
+	transport.ForwardingTimeouts(ctx).ResponseHeaderTimeout = &timeout
+
+	return fmt.Sprintf("%s is mapped to the responseHeaderTimeout of the ServersTransport; NGINX applies it between "+
+		"two successive reads of the response, Traefik only until the response headers are received", name), nil
+}
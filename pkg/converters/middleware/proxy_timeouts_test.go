@@ -0,0 +1,90 @@
+package middleware_test
+
+import (
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestProxyTimeouts(t *testing.T) {
+	t.Run("should set the forwarding timeouts of the ServersTransport", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ProxyConnectTimeout): "5",
+			string(models.ProxyReadTimeout):    "120",
+		})
+
+		middleware.ProxyTimeouts(ctx)
+
+		if len(ctx.Result.ServersTransports) != 1 {
+			t.Fatalf("expected a ServersTransport, got %d", len(ctx.Result.ServersTransports))
+		}
+
+		timeouts := ctx.Result.ServersTransports[0].Spec.ForwardingTimeouts
+		if timeouts == nil || timeouts.DialTimeout.String() != "5s" || timeouts.ResponseHeaderTimeout.String() != "120s" {
+			t.Fatalf("expected dialTimeout 5s and responseHeaderTimeout 120s, got %+v", timeouts)
+		}
+
+		if len(ctx.Result.Warnings) != 1 || !hasWarning(ctx, "proxy-read-timeout is mapped to the responseHeaderTimeout") {
+			t.Errorf("expected a single read timeout warning, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should skip the send timeout", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ProxySendTimeout): "60",
+		})
+
+		middleware.ProxyTimeouts(ctx)
+
+		if len(ctx.Result.ServersTransports) != 0 || !hasWarning(ctx, "proxy-send-timeout has no Traefik equivalent") {
+			t.Errorf("expected no ServersTransport and a warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
+
+func TestConfigurationSnippets_proxyTimeouts(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{value: "60", expected: "60s"},
+		{value: "60s", expected: "60s"},
+		{value: "500ms", expected: "500ms"},
+		{value: "1m", expected: "60s"},
+	}
+
+	for _, test := range tests {
+		t.Run("should parse the duration "+test.value, func(t *testing.T) {
+			ctx := newTestContext(map[string]string{
+				string(models.ConfigurationSnippet): "proxy_connect_timeout " + test.value + ";",
+			})
+
+			if err := middleware.ConfigurationSnippets(ctx); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(ctx.Result.ServersTransports) != 1 {
+				t.Fatalf("expected a ServersTransport, got %d", len(ctx.Result.ServersTransports))
+			}
+
+			if dial := ctx.Result.ServersTransports[0].Spec.ForwardingTimeouts.DialTimeout; dial.String() != test.expected {
+				t.Errorf("expected dialTimeout %s, got %s", test.expected, dial.String())
+			}
+		})
+	}
+
+	t.Run("should reject malformed durations", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): "proxy_read_timeout 1.5s;",
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !hasWarning(ctx, `proxy_read_timeout has an invalid value "1.5s"`) {
+			t.Errorf("expected invalid duration warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
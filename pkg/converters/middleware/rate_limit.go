@@ -1,71 +1,175 @@
 package middleware
 
 import (
+	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
 	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/errors"
 	traefik "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 /* ---------------- RATE LIMIT ---------------- */
 
+// defaultBurstMultiplier is the ingress-nginx default of limit-burst-multiplier.
+const defaultBurstMultiplier = 5
+
+// rateLimitBucketWarning explains the behavioral differences between the NGINX
+// leaky bucket and the Traefik token bucket.
+const rateLimitBucketWarning = "rate limits were converted to Traefik RateLimit, which uses a token bucket: " +
+	"a full burst is allowed again as soon as the tokens refill, while NGINX (leaky bucket with nodelay) " +
+	"spreads the burst over time; rejected requests are answered with 429 instead of the NGINX 503"
+
 // RateLimit handles the below annotations.
 // Annotations:
 //   - "nginx.ingress.kubernetes.io/limit-rps"
+//   - "nginx.ingress.kubernetes.io/limit-rpm"
 //   - "nginx.ingress.kubernetes.io/limit-burst-multiplier"
+//   - "nginx.ingress.kubernetes.io/limit-whitelist"
 func RateLimit(ctx configs.Context) error {
 	ctx.Log.Debug("running converter RateLimit")
 
 	annLimitRPS := string(models.LimitRPS)
+	annLimitRPM := string(models.LimitRPM)
 	annLimitBurstMultiplier := string(models.LimitBurstMultiplier)
 
-	rps, ok := ctx.Annotations[annLimitRPS]
-	if !ok {
+	rps, hasRPS := ctx.Annotations[annLimitRPS]
+	rpm, hasRPM := ctx.Annotations[annLimitRPM]
+
+	if !hasRPS && !hasRPM {
 		return nil
 	}
 
-	const averageValue = 2
+	multiplier := defaultBurstMultiplier
+
+	if val, ok := ctx.Annotations[annLimitBurstMultiplier]; ok {
+		parsed, err := strconv.Atoi(strings.TrimSpace(val))
+		if err != nil || parsed < 1 {
+			msg := fmt.Sprintf("limit-burst-multiplier %q is not a positive integer; defaulted to %d", val, defaultBurstMultiplier)
+
+			ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+			ctx.ReportWarning(annLimitBurstMultiplier, msg)
+		} else {
+			multiplier = parsed
+
+			ctx.ReportConverted(annLimitBurstMultiplier)
+		}
+	}
+
+	exempt := limitWhitelist(ctx)
+	names := make([]string, 0)
+
+	// NGINX enforces both limits when both annotations are set, chaining two
+	// RateLimit middlewares keeps that behavior.
+	limits := []struct {
+		ann, val, suffix, period string
+		present                  bool
+	}{
+		{ann: annLimitRPS, val: rps, suffix: "ratelimit", period: "1s", present: hasRPS},
+		{ann: annLimitRPM, val: rpm, suffix: "ratelimit-rpm", period: "1m", present: hasRPM},
+	}
+
+	for _, limit := range limits {
+		if !limit.present {
+			continue
+		}
+
+		avg, err := strconv.Atoi(strings.TrimSpace(limit.val))
+		if err != nil || avg < 1 {
+			msg := fmt.Sprintf("%s %q is not a positive integer", strings.TrimPrefix(limit.ann, "nginx.ingress.kubernetes.io/"), limit.val)
+
+			ctx.ReportWarning(limit.ann, msg)
+
+			return &errors.ConverterError{Message: msg}
+		}
+
+		mw := newRateLimitMiddleware(ctx, limit.suffix, avg, limit.period, avg*multiplier)
+
+		if len(exempt) > 0 {
+			mw.Annotations = map[string]string{
+				NoteAnnotation: "sources exempted from the rate limit are routed without this middleware: " + strings.Join(exempt, ", "),
+			}
+		}
 
-	avg, err := strconv.Atoi(rps)
-	if err != nil {
-		ctx.ReportWarning(annLimitRPS, err.Error())
-		ctx.ReportWarning(annLimitBurstMultiplier, err.Error())
+		ctx.Result.Middlewares = append(ctx.Result.Middlewares, mw)
+		names = append(names, mw.GetName())
 
-		return err
+		ctx.ReportConverted(limit.ann)
 	}
 
-	burst := avg * averageValue
+	if len(exempt) > 0 {
+		if ctx.Result.RateLimitExemptions == nil {
+			ctx.Result.RateLimitExemptions = make(map[string]*configs.RateLimitExemption)
+		}
 
-	if m := ctx.Annotations[annLimitBurstMultiplier]; m != "" {
-		if v, err := strconv.Atoi(m); err == nil {
-			burst = avg * v
+		ctx.Result.RateLimitExemptions[ctx.IngressName] = &configs.RateLimitExemption{
+			SourceRange: exempt,
+			Middlewares: names,
 		}
+
+		ctx.ReportConverted(string(models.LimitWhitelist))
 	}
 
+	ctx.Result.Warnings = append(ctx.Result.Warnings, rateLimitBucketWarning)
+
+	return nil
+}
+
+// limitWhitelist returns the valid sources of the limit-whitelist annotation.
+// Traefik RateLimit cannot exempt sources, the IngressRoute gets companion routes
+// matching the sources with ClientIP which skip the rate limit middlewares.
+func limitWhitelist(ctx configs.Context) []string {
+	ann := string(models.LimitWhitelist)
+
+	val, ok := ctx.Annotations[ann]
+	if !ok {
+		return nil
+	}
+
+	ranges, invalid := parseSourceRanges(strings.Split(val, ","))
+
+	if len(invalid) > 0 {
+		msg := "limit-whitelist entries are not valid IPs or CIDRs and were dropped: " + strings.Join(invalid, ", ")
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+	}
+
+	if len(ranges) == 0 {
+		msg := "limit-whitelist has no valid IP or CIDR entries: " + val
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportSkipped(ann, msg)
+
+		return nil
+	}
+
+	return ranges
+}
+
+func newRateLimitMiddleware(ctx configs.Context, suffix string, avg int, period string, burst int) *traefik.Middleware {
 	average := int64(avg)
 	averageBurst := int64(burst)
+	ratePeriod := intstr.FromString(period)
 
-	ctx.Result.Middlewares = append(ctx.Result.Middlewares, &traefik.Middleware{
+	return &traefik.Middleware{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: traefik.SchemeGroupVersion.String(),
 			Kind:       "Middleware",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      mwName(ctx, "ratelimit"),
+			Name:      mwName(ctx, suffix),
 			Namespace: ctx.Namespace,
 		},
 		Spec: traefik.MiddlewareSpec{
 			RateLimit: &traefik.RateLimit{
 				Average: &average,
+				Period:  &ratePeriod,
 				Burst:   &averageBurst,
 			},
 		},
-	})
-
-	ctx.ReportConverted(annLimitRPS)
-	ctx.ReportConverted(annLimitBurstMultiplier)
-
-	return nil
+	}
 }
@@ -0,0 +1,154 @@
+package middleware_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestRateLimit(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		suffix      string
+		wantAverage int64
+		wantPeriod  string
+		wantBurst   int64
+	}{
+		{
+			name:        "should use the default burst multiplier for limit-rps",
+			annotations: map[string]string{string(models.LimitRPS): "10"},
+			suffix:      "ratelimit",
+			wantAverage: 10,
+			wantPeriod:  "1s",
+			wantBurst:   50,
+		},
+		{
+			name: "should apply limit-burst-multiplier to limit-rps",
+			annotations: map[string]string{
+				string(models.LimitRPS):             "10",
+				string(models.LimitBurstMultiplier): "3",
+			},
+			suffix:      "ratelimit",
+			wantAverage: 10,
+			wantPeriod:  "1s",
+			wantBurst:   30,
+		},
+		{
+			name:        "should convert limit-rpm with a one minute period",
+			annotations: map[string]string{string(models.LimitRPM): "120"},
+			suffix:      "ratelimit-rpm",
+			wantAverage: 120,
+			wantPeriod:  "1m",
+			wantBurst:   600,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := newTestContext(tt.annotations)
+
+			if err := middleware.RateLimit(ctx); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			rateLimit := findMiddleware(t, ctx, tt.suffix).Spec.RateLimit
+			if rateLimit == nil {
+				t.Fatalf("expected a RateLimit middleware")
+			}
+
+			if *rateLimit.Average != tt.wantAverage {
+				t.Errorf("expected average %d, got %d", tt.wantAverage, *rateLimit.Average)
+			}
+
+			if rateLimit.Period.String() != tt.wantPeriod {
+				t.Errorf("expected period %s, got %s", tt.wantPeriod, rateLimit.Period.String())
+			}
+
+			if *rateLimit.Burst != tt.wantBurst {
+				t.Errorf("expected burst %d, got %d", tt.wantBurst, *rateLimit.Burst)
+			}
+
+			if !hasWarning(ctx, "token bucket") {
+				t.Errorf("expected the token bucket warning, got %v", ctx.Result.Warnings)
+			}
+		})
+	}
+
+	t.Run("should chain both limits when limit-rps and limit-rpm are set", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.LimitRPS): "5",
+			string(models.LimitRPM): "100",
+		})
+
+		if err := middleware.RateLimit(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		findMiddleware(t, ctx, "ratelimit")
+		findMiddleware(t, ctx, "ratelimit-rpm")
+	})
+
+	t.Run("should fail on an invalid limit-rps", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.LimitRPS): "ten",
+		})
+
+		if err := middleware.RateLimit(ctx); err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+
+	t.Run("should warn on an invalid limit-burst-multiplier", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.LimitRPS):             "10",
+			string(models.LimitBurstMultiplier): "zero",
+		})
+
+		if err := middleware.RateLimit(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if *findMiddleware(t, ctx, "ratelimit").Spec.RateLimit.Burst != 50 {
+			t.Errorf("expected the default burst multiplier to be used")
+		}
+
+		if !hasWarning(ctx, "limit-burst-multiplier \"zero\" is not a positive integer") {
+			t.Errorf("expected a multiplier warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
+
+func TestRateLimit_limitWhitelist(t *testing.T) {
+	t.Run("should record the exempt sources for companion routes", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.LimitRPS):       "10",
+			string(models.LimitWhitelist): "10.0.0.0/8, 192.168.1.1, nope",
+		})
+
+		if err := middleware.RateLimit(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		mw := findMiddleware(t, ctx, "ratelimit")
+
+		exemption := ctx.Result.RateLimitExemptions[ctx.IngressName]
+		if exemption == nil {
+			t.Fatalf("expected a rate limit exemption")
+		}
+
+		if expected := []string{"10.0.0.0/8", "192.168.1.1"}; !reflect.DeepEqual(expected, exemption.SourceRange) {
+			t.Errorf("expected exempt sources %v, got %v", expected, exemption.SourceRange)
+		}
+
+		if expected := []string{mw.GetName()}; !reflect.DeepEqual(expected, exemption.Middlewares) {
+			t.Errorf("expected exempt middlewares %v, got %v", expected, exemption.Middlewares)
+		}
+
+		if !hasWarning(ctx, "limit-whitelist entries are not valid IPs or CIDRs and were dropped: nope") {
+			t.Errorf("expected an invalid entry warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
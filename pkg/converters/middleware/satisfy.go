@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+/* ---------------- SATISFY ---------------- */
+
+// satisfyAnyWarning is shared by the satisfy annotation and the `satisfy any;`
+// snippet directive so both paths report the same guidance.
+const satisfyAnyWarning = "'satisfy any' grants access when either the IP allow list or authentication passes; " +
+	"Traefik chains middlewares with AND semantics, so the generated middlewares will require all checks to pass. " +
+	"Use separate routes or a ForwardAuth service to implement OR semantics"
+
+// Satisfy handles the below annotations.
+// Annotations:
+//   - "nginx.ingress.kubernetes.io/satisfy"
+func Satisfy(ctx configs.Context) {
+	ctx.Log.Debug("running converter Satisfy")
+
+	ann := string(models.Satisfy)
+
+	val, ok := ctx.Annotations[ann]
+	if !ok {
+		return
+	}
+
+	switch strings.ToLower(strings.TrimSpace(val)) {
+	case "all":
+		// Traefik chains middlewares with AND semantics, which is what satisfy all does.
+		ctx.ReportConverted(ann)
+	case "any":
+		ctx.Result.Warnings = append(ctx.Result.Warnings, satisfyAnyWarning)
+		ctx.ReportWarning(ann, satisfyAnyWarning)
+	default:
+		ctx.ReportSkipped(ann, "unsupported satisfy value "+val+"; expected 'all' or 'any'")
+	}
+}
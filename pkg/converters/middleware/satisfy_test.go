@@ -0,0 +1,55 @@
+package middleware_test
+
+import (
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestSatisfy(t *testing.T) {
+	t.Run("should warn about OR semantics for the satisfy annotation", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.Satisfy): "any",
+		})
+
+		middleware.Satisfy(ctx)
+
+		if !hasWarning(ctx, "'satisfy any' grants access") {
+			t.Errorf("expected satisfy any warning, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should warn about OR semantics for satisfy any in a snippet", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `satisfy any;
+allow 10.0.0.0/8;`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !hasWarning(ctx, "configuration-snippet: 'satisfy any' grants access") {
+			t.Errorf("expected satisfy any warning, got %v", ctx.Result.Warnings)
+		}
+
+		if hasWarning(ctx, "ignored: satisfy any") {
+			t.Errorf("expected satisfy directive to be recognised, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should report satisfy all as converted", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.Satisfy): "all",
+		})
+
+		middleware.Satisfy(ctx)
+
+		entries := ctx.Result.IngressReport.Entries
+		if len(entries) != 1 || entries[0].Status != configs.AnnotationConverted {
+			t.Errorf("expected satisfy all to be reported as converted, got %v", entries)
+		}
+	})
+}
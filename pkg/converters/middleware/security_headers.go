@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+/* ---------------- SECURITY HEADERS ---------------- */
+
+// applySecurityHeaders moves the security response headers that Traefik models
+// natively out of the generic CustomResponseHeaders map and into their
+// dedicated Headers fields. Unrecognised headers are left untouched.
+func applySecurityHeaders(headers *dynamic.Headers, warnings *[]string) {
+	for _, key := range slices.Sorted(maps.Keys(headers.CustomResponseHeaders)) {
+		val := headers.CustomResponseHeaders[key]
+
+		switch strings.ToLower(key) {
+		case "x-frame-options":
+			value := strings.ToUpper(strings.TrimSpace(val))
+
+			switch {
+			case value == "SAMEORIGIN", value == "DENY":
+				headers.CustomFrameOptionsValue = value
+			case strings.HasPrefix(value, "ALLOW-FROM"):
+				*warnings = append(*warnings, fmt.Sprintf(
+					"X-Frame-Options %q uses ALLOW-FROM which is deprecated and ignored by modern browsers; "+
+						"consider Content-Security-Policy 'frame-ancestors' instead", val,
+				))
+
+				headers.CustomFrameOptionsValue = strings.TrimSpace(val)
+			default:
+				*warnings = append(*warnings, fmt.Sprintf(
+					"X-Frame-Options has unexpected value %q (expected DENY or SAMEORIGIN); kept as a custom response header", val,
+				))
+
+				continue
+			}
+
+		case "x-content-type-options":
+			// Traefik only models the nosniff value, anything else stays a raw header.
+			if !strings.EqualFold(strings.TrimSpace(val), "nosniff") {
+				*warnings = append(*warnings, fmt.Sprintf(
+					"X-Content-Type-Options has unexpected value %q (only 'nosniff' is valid); kept as a custom response header", val,
+				))
+
+				continue
+			}
+
+			headers.ContentTypeNosniff = true
+
+		case "referrer-policy":
+			headers.ReferrerPolicy = val
+
+		case "content-security-policy":
+			headers.ContentSecurityPolicy = val
+
+		case "strict-transport-security":
+			if !applyHSTS(headers, val, warnings) {
+				continue
+			}
+
+		default:
+			continue
+		}
+
+		delete(headers.CustomResponseHeaders, key)
+	}
+}
+
+// hstsPreloadMinMaxAge is the minimum max-age, one year, accepted by the HSTS preload list.
+const hstsPreloadMinMaxAge = 31536000
+
+// applyHSTS maps a Strict-Transport-Security value to the STS fields of the
+// Headers middleware and validates the preload requirements. It returns false
+// when the value cannot be parsed and has to stay a custom response header.
+func applyHSTS(headers *dynamic.Headers, val string, warnings *[]string) bool {
+	var (
+		maxAge            int64
+		hasMaxAge         bool
+		includeSubDomains bool
+		preload           bool
+	)
+
+	for _, part := range strings.Split(val, ";") {
+		name, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "max-age":
+			seconds, err := strconv.ParseInt(strings.Trim(strings.TrimSpace(value), `"`), 10, 64)
+			if err != nil || seconds < 0 {
+				break
+			}
+
+			maxAge, hasMaxAge = seconds, true
+		case "includesubdomains":
+			includeSubDomains = true
+		case "preload":
+			preload = true
+		}
+	}
+
+	if !hasMaxAge {
+		*warnings = append(*warnings, fmt.Sprintf(
+			"Strict-Transport-Security %q has no valid max-age; kept as a custom response header", val,
+		))
+
+		return false
+	}
+
+	headers.STSSeconds = maxAge
+	headers.STSIncludeSubdomains = includeSubDomains
+	headers.STSPreload = preload
+
+	if preload && (maxAge < hstsPreloadMinMaxAge || !includeSubDomains) {
+		*warnings = append(*warnings, fmt.Sprintf(
+			"Strict-Transport-Security %q sets preload but the preload list requires max-age of at least %d "+
+				"and includeSubDomains; browsers will reject the preload directive", val, hstsPreloadMinMaxAge,
+		))
+	}
+
+	return true
+}
@@ -0,0 +1,169 @@
+package middleware_test
+
+import (
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestConfigurationSnippets_securityHeaders(t *testing.T) {
+	t.Run("should map recognised security headers to structured fields", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `add_header X-Frame-Options "SAMEORIGIN";
+add_header Content-Security-Policy "default-src 'self'";
+add_header Referrer-Policy "no-referrer";
+add_header X-Custom "value";`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		headers := findMiddleware(t, ctx, "configuration-snippet").Spec.Headers
+
+		if headers.CustomFrameOptionsValue != "SAMEORIGIN" {
+			t.Errorf("expected CustomFrameOptionsValue SAMEORIGIN, got %q", headers.CustomFrameOptionsValue)
+		}
+
+		if headers.ContentSecurityPolicy != "default-src 'self'" {
+			t.Errorf("expected ContentSecurityPolicy to be set, got %q", headers.ContentSecurityPolicy)
+		}
+
+		if headers.ReferrerPolicy != "no-referrer" {
+			t.Errorf("expected ReferrerPolicy no-referrer, got %q", headers.ReferrerPolicy)
+		}
+
+		if _, ok := headers.CustomResponseHeaders["X-Frame-Options"]; ok {
+			t.Errorf("X-Frame-Options should not remain in custom response headers")
+		}
+
+		if headers.CustomResponseHeaders["X-Custom"] != "value" {
+			t.Errorf("expected unrecognised header to stay in custom response headers")
+		}
+	})
+}
+
+func TestConfigurationSnippets_contentTypeNosniff(t *testing.T) {
+	t.Run("should map nosniff to the ContentTypeNosniff field", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `add_header X-Content-Type-Options nosniff;`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		headers := findMiddleware(t, ctx, "configuration-snippet").Spec.Headers
+
+		if !headers.ContentTypeNosniff {
+			t.Errorf("expected ContentTypeNosniff to be true")
+		}
+
+		if _, ok := headers.CustomResponseHeaders["X-Content-Type-Options"]; ok {
+			t.Errorf("X-Content-Type-Options should not remain in custom response headers")
+		}
+	})
+
+	t.Run("should warn and keep the raw header for values other than nosniff", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `add_header X-Content-Type-Options sniff;`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		headers := findMiddleware(t, ctx, "configuration-snippet").Spec.Headers
+
+		if headers.ContentTypeNosniff {
+			t.Errorf("expected ContentTypeNosniff to be false")
+		}
+
+		if !hasWarning(ctx, "X-Content-Type-Options has unexpected value") {
+			t.Errorf("expected a warning for the unexpected value, got %v", ctx.Result.Warnings)
+		}
+	})
+}
+
+func TestConfigurationSnippets_frameOptions(t *testing.T) {
+	t.Run("should map SAMEORIGIN to CustomFrameOptionsValue", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `add_header X-Frame-Options sameorigin;`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		headers := findMiddleware(t, ctx, "configuration-snippet").Spec.Headers
+
+		if headers.CustomFrameOptionsValue != "SAMEORIGIN" {
+			t.Errorf("expected CustomFrameOptionsValue SAMEORIGIN, got %q", headers.CustomFrameOptionsValue)
+		}
+
+		if hasWarning(ctx, "X-Frame-Options") {
+			t.Errorf("expected no X-Frame-Options warning, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should warn about the deprecated ALLOW-FROM value", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `add_header X-Frame-Options "ALLOW-FROM https://example.com";`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		headers := findMiddleware(t, ctx, "configuration-snippet").Spec.Headers
+
+		if headers.CustomFrameOptionsValue != "ALLOW-FROM https://example.com" {
+			t.Errorf("unexpected CustomFrameOptionsValue %q", headers.CustomFrameOptionsValue)
+		}
+
+		if !hasWarning(ctx, "frame-ancestors") {
+			t.Errorf("expected a frame-ancestors suggestion, got %v", ctx.Result.Warnings)
+		}
+	})
+}
+
+func TestConfigurationSnippets_hsts(t *testing.T) {
+	t.Run("should map HSTS to the structured STS fields", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `add_header Strict-Transport-Security "max-age=63072000; includeSubDomains; preload" always;`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		headers := findMiddleware(t, ctx, "configuration-snippet").Spec.Headers
+
+		if headers.STSSeconds != 63072000 || !headers.STSIncludeSubdomains || !headers.STSPreload {
+			t.Errorf("expected structured HSTS fields, got %+v", headers)
+		}
+
+		if _, ok := headers.CustomResponseHeaders["Strict-Transport-Security"]; ok {
+			t.Errorf("Strict-Transport-Security should not remain in custom response headers")
+		}
+
+		if hasWarning(ctx, "preload") {
+			t.Errorf("expected no preload warning, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should warn when preload is set with a too short max-age", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.ConfigurationSnippet): `add_header Strict-Transport-Security "max-age=86400; includeSubDomains; preload";`,
+		})
+
+		if err := middleware.ConfigurationSnippets(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !hasWarning(ctx, "browsers will reject the preload directive") {
+			t.Errorf("expected preload warning, got %v", ctx.Result.Warnings)
+		}
+	})
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/errors"
 )
 
+// parseSizeBytes parses an NGINX size value such as "512", "8k", "8m" or "1g"
+// into bytes. The suffixes are case-insensitive and use powers of 1024.
 func parseSizeBytes(val string) (int64, error) {
 	value := strings.TrimSpace(strings.ToLower(val))
 
@@ -28,7 +30,7 @@ func parseSizeBytes(val string) (int64, error) {
 	}
 
 	n, err := strconv.ParseInt(value, 10, 64)
-	if err != nil {
+	if err != nil || n < 0 {
 		return 0, &errors.ConverterError{Message: fmt.Sprintf("invalid size value: %s", val)}
 	}
 
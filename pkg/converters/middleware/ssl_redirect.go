@@ -38,7 +38,15 @@ func SSLRedirect(ctx configs.Context) {
 		return
 	}
 
-	ctx.Result.Middlewares = append(ctx.Result.Middlewares, &traefik.Middleware{
+	ctx.Result.Middlewares = append(ctx.Result.Middlewares, newHTTPSRedirectMiddleware(ctx, true))
+
+	ctx.ReportConverted(annSSLRedirect)
+
+	ctx.ReportConverted(annForceSslRedirect)
+}
+
+func newHTTPSRedirectMiddleware(ctx configs.Context, permanent bool) *traefik.Middleware {
+	return &traefik.Middleware{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: traefik.SchemeGroupVersion.String(),
 			Kind:       "Middleware",
@@ -50,12 +58,8 @@ func SSLRedirect(ctx configs.Context) {
 		Spec: traefik.MiddlewareSpec{
 			RedirectScheme: &dynamic.RedirectScheme{
 				Scheme:    "https",
-				Permanent: true,
+				Permanent: permanent,
 			},
 		},
-	})
-
-	ctx.ReportConverted(annSSLRedirect)
-
-	ctx.ReportConverted(annForceSslRedirect)
+	}
 }
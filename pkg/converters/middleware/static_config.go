@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"sigs.k8s.io/yaml"
+)
+
+/* ---------------- Static configuration snippets ---------------- */
+
+// staticSetting is a single Traefik static configuration value, addressed by its
+// dotted path, e.g. "entryPoints.web.transport.respondingTimeouts.idleTimeout".
+type staticSetting struct {
+	path  string
+	value any
+}
+
+// staticConfigGuidance returns the guidance message for settings that live in
+// the Traefik static configuration. With Options.SuggestYAML enabled a ready to
+// paste YAML snippet of the settings is appended to the message.
+func staticConfigGuidance(ctx configs.Context, msg string, settings ...staticSetting) string {
+	if !ctx.Options.SuggestYAML || len(settings) == 0 {
+		return msg
+	}
+
+	snippet, err := staticConfigYAML(settings...)
+	if err != nil {
+		ctx.Log.Debug("failed to render static configuration snippet", "error", err)
+
+		return msg
+	}
+
+	return msg + ", for example:\n" + snippet
+}
+
+// staticConfigYAML renders the settings as a single YAML document, merging the
+// settings sharing a path prefix.
+func staticConfigYAML(settings ...staticSetting) (string, error) {
+	root := make(map[string]any)
+
+	for _, setting := range settings {
+		keys := strings.Split(setting.path, ".")
+		node := root
+
+		for _, key := range keys[:len(keys)-1] {
+			child, ok := node[key].(map[string]any)
+			if !ok {
+				child = make(map[string]any)
+				node[key] = child
+			}
+
+			node = child
+		}
+
+		node[keys[len(keys)-1]] = setting.value
+	}
+
+	out, err := yaml.Marshal(root)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
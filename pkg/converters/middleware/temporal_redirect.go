@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+/* ---------------- TEMPORAL REDIRECT ---------------- */
+
+// TemporalRedirect handles the below annotations.
+// Annotations:
+//   - "nginx.ingress.kubernetes.io/temporal-redirect"
+func TemporalRedirect(ctx configs.Context) {
+	ctx.Log.Debug("running converter TemporalRedirect")
+
+	ann := string(models.TemporalRedirect)
+
+	target, ok := ctx.Annotations[ann]
+	if !ok || strings.TrimSpace(target) == "" {
+		return
+	}
+
+	if err := validateRedirectTarget(target); err != nil {
+		msg := "temporal-redirect " + err.Error()
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportSkipped(ann, msg)
+
+		return
+	}
+
+	// NGINX answers with a 302, Traefik with a 302 for GET and a 307 otherwise.
+	ctx.Result.Middlewares = append(ctx.Result.Middlewares,
+		newRedirectRegexMiddleware(ctx, "temporal-redirect", &dynamic.RedirectRegex{
+			Regex:       "^.*$",
+			Replacement: strings.TrimSpace(target),
+			Permanent:   false,
+		}),
+	)
+
+	ctx.ReportConverted(ann)
+}
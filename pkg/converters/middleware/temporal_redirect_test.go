@@ -0,0 +1,55 @@
+package middleware_test
+
+import (
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestTemporalRedirect(t *testing.T) {
+	t.Run("should emit a temporary redirect to the target", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.TemporalRedirect): "https://example.com/maintenance",
+		})
+
+		middleware.TemporalRedirect(ctx)
+
+		redirect := findMiddleware(t, ctx, "temporal-redirect").Spec.RedirectRegex
+		if redirect == nil || redirect.Permanent || redirect.Replacement != "https://example.com/maintenance" {
+			t.Errorf("unexpected redirect %+v", redirect)
+		}
+	})
+
+	t.Run("should skip a target that is not an absolute URL", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.TemporalRedirect): "example.com/maintenance",
+		})
+
+		middleware.TemporalRedirect(ctx)
+
+		if len(ctx.Result.Middlewares) != 0 {
+			t.Errorf("expected no middleware, got %d", len(ctx.Result.Middlewares))
+		}
+
+		if !hasWarning(ctx, "is not an absolute http(s) URL") {
+			t.Errorf("expected a target warning, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should take precedence over permanent-redirect", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.TemporalRedirect):  "https://example.com/maintenance",
+			string(models.PermanentRedirect): "https://example.com/new",
+		})
+
+		middleware.PermanentRedirect(ctx)
+		middleware.TemporalRedirect(ctx)
+
+		if len(ctx.Result.Middlewares) != 1 {
+			t.Fatalf("expected a single redirect middleware, got %d", len(ctx.Result.Middlewares))
+		}
+
+		findMiddleware(t, ctx, "temporal-redirect")
+	})
+}
@@ -17,7 +17,7 @@ func EnableUnderscoresInHeaders(ctx configs.Context) {
 		return
 	}
 
-	ctx.ReportIgnored(
+	ctx.ReportDefault(
 		ann,
 		"Traefik accepts headers with underscores by default (it uses Go HTTP parser); no equivalent configuration is required",
 	)
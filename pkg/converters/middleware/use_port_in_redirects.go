@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+/* ---------------- USE PORT IN REDIRECTS ---------------- */
+
+// UsePortInRedirects handles the below annotations.
+// Annotations:
+//   - "nginx.ingress.kubernetes.io/use-port-in-redirects"
+//
+// NGINX then adds the listening port to the redirects it issues. Traefik always drops the
+// default port 443 from https redirects, only a non-default port set on the RedirectScheme
+// ends up in the Location header.
+func UsePortInRedirects(ctx configs.Context) {
+	ctx.Log.Debug("running converter UsePortInRedirects")
+
+	ann := string(models.UsePortInRedirects)
+
+	val, ok := ctx.Annotations[ann]
+	if !ok {
+		return
+	}
+
+	usePort, err := strconv.ParseBool(strings.TrimSpace(val))
+	if err != nil {
+		msg := "use-port-in-redirects is not a boolean: " + val
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportSkipped(ann, msg)
+
+		return
+	}
+
+	if !usePort {
+		ctx.ReportDefault(ann, "redirects without a port are the Traefik default")
+
+		return
+	}
+
+	for _, mw := range ctx.Result.Middlewares {
+		if mw.GetName() != mwName(ctx, "https-redirect") || mw.Spec.RedirectScheme == nil {
+			continue
+		}
+
+		msg := "use-port-in-redirects: Traefik omits port 443 from https redirects; if the websecure entrypoint " +
+			"(container port 8443 in the Traefik Helm chart) is published on another port by the Traefik service, " +
+			"set that port on the https-redirect middleware"
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportWarning(ann, msg)
+
+		return
+	}
+
+	msg := "use-port-in-redirects has no generated redirect to apply to, " +
+		"redirects issued by the backend keep the port they were built with"
+
+	ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+	ctx.ReportWarning(ann, msg)
+}
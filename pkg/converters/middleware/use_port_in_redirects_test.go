@@ -0,0 +1,50 @@
+package middleware_test
+
+import (
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestUsePortInRedirects(t *testing.T) {
+	t.Run("should warn about the published port of the ssl redirect", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.SSLRedirect):        "true",
+			string(models.UsePortInRedirects): "true",
+		})
+
+		middleware.SSLRedirect(ctx)
+		middleware.UsePortInRedirects(ctx)
+
+		mw := findMiddleware(t, ctx, "https-redirect")
+
+		if mw.Spec.RedirectScheme.Port != "" {
+			t.Errorf("expected no port, got %q", mw.Spec.RedirectScheme.Port)
+		}
+
+		if !hasWarning(ctx, "websecure entrypoint") {
+			t.Errorf("expected entrypoint warning, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should warn when no redirect is generated", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{string(models.UsePortInRedirects): "true"})
+
+		middleware.UsePortInRedirects(ctx)
+
+		if !hasWarning(ctx, "no generated redirect") {
+			t.Errorf("expected warning, got %v", ctx.Result.Warnings)
+		}
+	})
+
+	t.Run("should ignore false", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{string(models.UsePortInRedirects): "false"})
+
+		middleware.UsePortInRedirects(ctx)
+
+		if len(ctx.Result.Warnings) != 0 {
+			t.Errorf("expected no warnings, got %v", ctx.Result.Warnings)
+		}
+	})
+}
@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"net"
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	traefik "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+/* ---------------- WHITELIST SOURCE RANGE ---------------- */
+
+// whitelistDenyNote explains that IPAllowList keeps the NGINX whitelist semantics.
+const whitelistDenyNote = "requests from sources outside of the sourceRange are denied by default, " +
+	"matching the NGINX whitelist-source-range behavior"
+
+// WhitelistSourceRange handles the below annotations.
+// Annotations:
+//   - "nginx.ingress.kubernetes.io/whitelist-source-range"
+func WhitelistSourceRange(ctx configs.Context) {
+	ctx.Log.Debug("running converter WhitelistSourceRange")
+
+	ann := string(models.WhitelistSourceRange)
+
+	val, ok := ctx.Annotations[ann]
+	if !ok {
+		return
+	}
+
+	ranges, invalid := parseSourceRanges(strings.Split(val, ","))
+
+	if len(ranges) == 0 {
+		msg := "whitelist-source-range has no valid IP or CIDR entries: " + val
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportSkipped(ann, msg)
+
+		return
+	}
+
+	ctx.Result.Middlewares = append(ctx.Result.Middlewares, &traefik.Middleware{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: traefik.SchemeGroupVersion.String(),
+			Kind:       "Middleware",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mwName(ctx, "whitelist-source-range"),
+			Namespace: ctx.Namespace,
+			Annotations: map[string]string{
+				NoteAnnotation: whitelistDenyNote,
+			},
+		},
+		Spec: traefik.MiddlewareSpec{
+			IPAllowList: &dynamic.IPAllowList{
+				SourceRange: ranges,
+			},
+		},
+	})
+
+	if len(invalid) > 0 {
+		msg := "whitelist-source-range entries are not valid IPs or CIDRs and were dropped: " + strings.Join(invalid, ", ")
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportWarning(ann, msg)
+
+		return
+	}
+
+	ctx.ReportConverted(ann)
+}
+
+// parseSourceRanges splits the given sources into valid IPs or CIDRs and invalid
+// entries, skipping blank ones.
+func parseSourceRanges(sources []string) ([]string, []string) {
+	ranges := make([]string, 0, len(sources))
+	invalid := make([]string, 0)
+
+	for _, raw := range sources {
+		source := strings.TrimSpace(raw)
+		if source == "" {
+			continue
+		}
+
+		if _, _, err := net.ParseCIDR(source); err != nil && net.ParseIP(source) == nil {
+			invalid = append(invalid, source)
+
+			continue
+		}
+
+		ranges = append(ranges, source)
+	}
+
+	return ranges, invalid
+}
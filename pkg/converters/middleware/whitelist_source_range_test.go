@@ -0,0 +1,56 @@
+package middleware_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/middleware"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+func TestWhitelistSourceRange(t *testing.T) {
+	t.Run("should convert to an IPAllowList with a deny-by-default note", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.WhitelistSourceRange): "10.0.0.0/8, 192.168.1.10",
+		})
+
+		middleware.WhitelistSourceRange(ctx)
+
+		mw := findMiddleware(t, ctx, "whitelist-source-range")
+
+		expected := []string{"10.0.0.0/8", "192.168.1.10"}
+		if !reflect.DeepEqual(mw.Spec.IPAllowList.SourceRange, expected) {
+			t.Errorf("expected source range %v, got %v", expected, mw.Spec.IPAllowList.SourceRange)
+		}
+
+		if mw.Annotations[middleware.NoteAnnotation] == "" {
+			t.Errorf("expected deny-by-default note, got %v", mw.Annotations)
+		}
+
+		if len(ctx.Result.Warnings) != 0 {
+			t.Errorf("expected the note not to be a warning, got %v", ctx.Result.Warnings)
+		}
+
+		if status := ctx.Result.IngressReport.Entries[0].Status; status != configs.AnnotationConverted {
+			t.Errorf("expected annotation to be reported as converted, got %s", status)
+		}
+	})
+
+	t.Run("should drop invalid entries with a warning", func(t *testing.T) {
+		ctx := newTestContext(map[string]string{
+			string(models.WhitelistSourceRange): "10.0.0.0/8,not-an-ip",
+		})
+
+		middleware.WhitelistSourceRange(ctx)
+
+		mw := findMiddleware(t, ctx, "whitelist-source-range")
+		if !reflect.DeepEqual(mw.Spec.IPAllowList.SourceRange, []string{"10.0.0.0/8"}) {
+			t.Errorf("expected only the valid range, got %v", mw.Spec.IPAllowList.SourceRange)
+		}
+
+		if !hasWarning(ctx, "not-an-ip") {
+			t.Errorf("expected warning about the invalid entry, got %v", ctx.Result.Warnings)
+		}
+	})
+}
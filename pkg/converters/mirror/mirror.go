@@ -0,0 +1,228 @@
+package mirror
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/errors"
+	traefik "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// requestURI is the NGINX variable mirroring the request with its original URI, which
+// is what Traefik does for every mirror.
+const requestURI = "$request_uri"
+
+// HandleMirrorTarget handles the below annotations.
+// Annotations:
+//   - "nginx.ingress.kubernetes.io/mirror-target"
+//   - "nginx.ingress.kubernetes.io/mirror-host"
+//   - "nginx.ingress.kubernetes.io/mirror-request-body"
+//
+// Traefik mirrors to Kubernetes Services only, the mirror-target has to address a
+// Service of the cluster by its name or its cluster domain.
+func HandleMirrorTarget(ctx configs.Context) {
+	ann := string(models.MirrorTarget)
+
+	val, ok := ctx.Annotations[ann]
+	if !ok {
+		if _, ok := ctx.Annotations[string(models.MirrorRequestBody)]; ok {
+			ctx.ReportIgnored(string(models.MirrorRequestBody), "mirror-request-body has no effect without mirror-target")
+		}
+
+		return
+	}
+
+	service, warnings, err := mirrorService(ctx, val)
+	if err != nil {
+		ctx.Result.Warnings = append(ctx.Result.Warnings, err.Error())
+		ctx.ReportSkipped(ann, err.Error())
+
+		return
+	}
+
+	if ctx.Result.Mirrors == nil {
+		ctx.Result.Mirrors = make(map[string]*traefik.Mirroring)
+	}
+
+	mirroring := &traefik.Mirroring{Mirrors: []traefik.MirrorService{service}}
+	ctx.Result.Mirrors[ctx.IngressName] = mirroring
+
+	if len(warnings) > 0 {
+		ctx.Result.Warnings = append(ctx.Result.Warnings, warnings...)
+		ctx.ReportWarning(ann, strings.Join(warnings, "; "))
+	} else {
+		ctx.ReportConverted(ann)
+	}
+
+	if host, ok := ctx.Annotations[string(models.MirrorHost)]; ok {
+		msg := fmt.Sprintf("mirror-host %q cannot be configured in Traefik, mirrored requests keep the Host header of the original request", host)
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportWarning(string(models.MirrorHost), msg)
+	}
+
+	handleMirrorRequestBody(ctx, mirroring)
+}
+
+// handleMirrorRequestBody stops mirroring the request body, the mirror still receives
+// the request line and headers as it does in NGINX.
+func handleMirrorRequestBody(ctx configs.Context, mirroring *traefik.Mirroring) {
+	ann := string(models.MirrorRequestBody)
+
+	val, ok := ctx.Annotations[ann]
+	if !ok {
+		return
+	}
+
+	switch strings.ToLower(strings.TrimSpace(val)) {
+	case "off":
+		mirrorBody := false
+		mirroring.MirrorBody = &mirrorBody
+
+		ctx.ReportConverted(ann)
+	case "on":
+		ctx.ReportDefault(ann, "mirror-request-body on is the default of Traefik mirroring")
+	default:
+		msg := fmt.Sprintf("mirror-request-body has an invalid value %q (expected on or off), the request body is mirrored", val)
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportWarning(ann, msg)
+	}
+}
+
+// ApplyMirror routes every route of the IngressRoute through a mirroring TraefikService,
+// which forwards the requests to the route service and a copy of them to the mirror.
+func ApplyMirror(ingressRoute *traefik.IngressRoute, ctx configs.Context) {
+	mirroring, ok := ctx.Result.Mirrors[ctx.IngressName]
+	if !ok {
+		return
+	}
+
+	for index := range ingressRoute.Spec.Routes {
+		route := &ingressRoute.Spec.Routes[index]
+		if len(route.Services) != 1 {
+			continue
+		}
+
+		name := ctx.IngressName + "-mirror"
+		if index > 0 {
+			name = fmt.Sprintf("%s-mirror-%d", ctx.IngressName, index)
+		}
+
+		spec := mirroring.DeepCopy()
+		spec.LoadBalancerSpec = route.Services[0].LoadBalancerSpec
+
+		ctx.Result.TraefikServices = append(ctx.Result.TraefikServices, &traefik.TraefikService{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: traefik.SchemeGroupVersion.String(),
+				Kind:       "TraefikService",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: ctx.Namespace,
+			},
+			Spec: traefik.TraefikServiceSpec{
+				Mirroring: spec,
+			},
+		})
+
+		route.Services = []traefik.Service{{
+			LoadBalancerSpec: traefik.LoadBalancerSpec{
+				Name: name,
+				Kind: "TraefikService",
+			},
+		}}
+	}
+}
+
+// mirrorService resolves the Kubernetes Service the mirror-target addresses, along with
+// warnings for the parts of the target Traefik cannot reproduce.
+func mirrorService(ctx configs.Context, target string) (traefik.MirrorService, []string, error) {
+	target = strings.TrimSpace(target)
+	warnings := make([]string, 0)
+
+	base, template := target, ""
+	if index := strings.Index(target, "$"); index >= 0 {
+		base, template = target[:index], target[index:]
+	}
+
+	parsed, err := url.Parse(base)
+	if err != nil || parsed.Hostname() == "" {
+		return traefik.MirrorService{}, nil, &errors.ConverterError{Message: fmt.Sprintf(
+			"mirror-target %q is not a valid URL, no mirroring was generated", target,
+		)}
+	}
+
+	name, namespace, ok := serviceOf(parsed.Hostname(), ctx.Namespace)
+	if !ok {
+		return traefik.MirrorService{}, nil, &errors.ConverterError{Message: fmt.Sprintf(
+			"mirror-target host %q is not a Kubernetes Service, Traefik only mirrors to Services; "+
+				"create an ExternalName Service for it and point the mirror at that Service", parsed.Hostname(),
+		)}
+	}
+
+	port := int64(80)
+	if parsed.Scheme == "https" {
+		port = 443
+	}
+
+	if parsed.Port() != "" {
+		if port, err = strconv.ParseInt(parsed.Port(), 10, 32); err != nil {
+			return traefik.MirrorService{}, nil, &errors.ConverterError{Message: fmt.Sprintf(
+				"mirror-target %q has an invalid port, no mirroring was generated", target,
+			)}
+		}
+	}
+
+	if uri := strings.TrimSuffix(parsed.Path, "/") + template; uri != "" && uri != requestURI {
+		warnings = append(warnings, fmt.Sprintf(
+			"mirror-target URI %q cannot be reproduced, Traefik mirrors requests with their original URI", uri,
+		))
+	}
+
+	if namespace != ctx.Namespace {
+		warnings = append(warnings, fmt.Sprintf(
+			"mirror-target Service %s/%s is in another namespace, "+
+				"enable allowCrossNamespace of the kubernetesCRD provider in the Traefik static configuration", namespace, name,
+		))
+	}
+
+	service := traefik.MirrorService{
+		LoadBalancerSpec: traefik.LoadBalancerSpec{
+			Name: name,
+			Port: intstr.FromInt32(int32(port)),
+		},
+		Percent: 100,
+	}
+
+	if namespace != ctx.Namespace {
+		service.Namespace = namespace
+	}
+
+	if parsed.Scheme == "https" {
+		service.Scheme = "https"
+	}
+
+	return service, warnings, nil
+}
+
+// serviceOf returns the name and namespace of the Service addressed by a cluster
+// host, which is either the Service name or its <name>.<namespace>.svc domain.
+func serviceOf(host, namespace string) (string, string, bool) {
+	labels := strings.Split(host, ".")
+
+	switch {
+	case len(labels) == 1:
+		return labels[0], namespace, true
+	case len(labels) >= 3 && labels[2] == "svc":
+		return labels[0], labels[1], true
+	default:
+		return "", "", false
+	}
+}
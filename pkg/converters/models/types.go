@@ -6,10 +6,21 @@ const (
 	AuthType                 Annotation = "nginx.ingress.kubernetes.io/auth-type"
 	AuthSecret               Annotation = "nginx.ingress.kubernetes.io/auth-secret" //nolint:gosec
 	AuthRealm                Annotation = "nginx.ingress.kubernetes.io/auth-realm"
+	AuthSecretType           Annotation = "nginx.ingress.kubernetes.io/auth-secret-type" //nolint:gosec
 	AuthTLSVerifyClient      Annotation = "nginx.ingress.kubernetes.io/auth-tls-verify-client"
 	AuthTLSSecret            Annotation = "nginx.ingress.kubernetes.io/auth-tls-secret" //nolint:gosec
+	AuthTLSVerifyDepth       Annotation = "nginx.ingress.kubernetes.io/auth-tls-verify-depth"
+	AuthTLSPassCertificate   Annotation = "nginx.ingress.kubernetes.io/auth-tls-pass-certificate-to-upstream"
 	AuthURL                  Annotation = "nginx.ingress.kubernetes.io/auth-url"
+	AuthSignin               Annotation = "nginx.ingress.kubernetes.io/auth-signin"
+	AuthMethod               Annotation = "nginx.ingress.kubernetes.io/auth-method"
+	AuthResponseHeaders      Annotation = "nginx.ingress.kubernetes.io/auth-response-headers"
+	AuthRequestRedirect      Annotation = "nginx.ingress.kubernetes.io/auth-request-redirect"
+	AuthProxySetHeaders      Annotation = "nginx.ingress.kubernetes.io/auth-proxy-set-headers"
+	AuthSnippet              Annotation = "nginx.ingress.kubernetes.io/auth-snippet"
+	EnableGlobalAuth         Annotation = "nginx.ingress.kubernetes.io/enable-global-auth"
 	ProxyBodySize            Annotation = "nginx.ingress.kubernetes.io/proxy-body-size"
+	ClientBodyBufferSize     Annotation = "nginx.ingress.kubernetes.io/client-body-buffer-size"
 	ConfigurationSnippet     Annotation = "nginx.ingress.kubernetes.io/configuration-snippet"
 	EnableCORS               Annotation = "nginx.ingress.kubernetes.io/enable-cors"
 	CorsAllowOrigin          Annotation = "nginx.ingress.kubernetes.io/cors-allow-origin"
@@ -23,14 +34,47 @@ const (
 	EnableOpentracing        Annotation = "nginx.ingress.kubernetes.io/enable-opentracing"
 	EnableOpentelemetry      Annotation = "nginx.ingress.kubernetes.io/enable-opentelemetry"
 	BackendProtocol          Annotation = "nginx.ingress.kubernetes.io/backend-protocol"
+	ProxyHTTPVersion         Annotation = "nginx.ingress.kubernetes.io/proxy-http-version"
+	ProxySSLSecret           Annotation = "nginx.ingress.kubernetes.io/proxy-ssl-secret" //nolint:gosec
+	ProxySSLVerify           Annotation = "nginx.ingress.kubernetes.io/proxy-ssl-verify"
+	ProxySSLVerifyDepth      Annotation = "nginx.ingress.kubernetes.io/proxy-ssl-verify-depth"
+	ProxySSLName             Annotation = "nginx.ingress.kubernetes.io/proxy-ssl-name"
+	ProxySSLServerName       Annotation = "nginx.ingress.kubernetes.io/proxy-ssl-server-name"
+	ProxySSLCiphers          Annotation = "nginx.ingress.kubernetes.io/proxy-ssl-ciphers"
+	ProxySSLProtocols        Annotation = "nginx.ingress.kubernetes.io/proxy-ssl-protocols"
+	UpstreamKeepalive        Annotation = "nginx.ingress.kubernetes.io/upstream-keepalive-connections"
+	UpstreamKeepaliveTimeout Annotation = "nginx.ingress.kubernetes.io/upstream-keepalive-timeout"
+	UpstreamKeepaliveTime    Annotation = "nginx.ingress.kubernetes.io/upstream-keepalive-time"
+	UpstreamKeepaliveReqs    Annotation = "nginx.ingress.kubernetes.io/upstream-keepalive-requests"
 	GrpcBackend              Annotation = "nginx.ingress.kubernetes.io/grpc-backend"
 	ProxyBufferSize          Annotation = "nginx.ingress.kubernetes.io/proxy-buffer-size"
 	LimitRPS                 Annotation = "nginx.ingress.kubernetes.io/limit-rps"
+	LimitRPM                 Annotation = "nginx.ingress.kubernetes.io/limit-rpm"
+	LimitWhitelist           Annotation = "nginx.ingress.kubernetes.io/limit-whitelist"
+	GlobalRateLimit          Annotation = "nginx.ingress.kubernetes.io/global-rate-limit"
+	GlobalRateLimitWindow    Annotation = "nginx.ingress.kubernetes.io/global-rate-limit-window"
+	GlobalRateLimitKey       Annotation = "nginx.ingress.kubernetes.io/global-rate-limit-key"
 	LimitBurstMultiplier     Annotation = "nginx.ingress.kubernetes.io/limit-burst-multiplier"
 	RewriteTarget            Annotation = "nginx.ingress.kubernetes.io/rewrite-target"
+	PreserveTrailingSlash    Annotation = "nginx.ingress.kubernetes.io/preserve-trailing-slash"
+	UsePortInRedirects       Annotation = "nginx.ingress.kubernetes.io/use-port-in-redirects"
 	SSLRedirect              Annotation = "nginx.ingress.kubernetes.io/ssl-redirect"
 	ForceSSLRedirect         Annotation = "nginx.ingress.kubernetes.io/force-ssl-redirect"
 	UpstreamVhost            Annotation = "nginx.ingress.kubernetes.io/upstream-vhost"
+	ConnectionProxyHeader    Annotation = "nginx.ingress.kubernetes.io/connection-proxy-header"
+	UpstreamHashBy           Annotation = "nginx.ingress.kubernetes.io/upstream-hash-by"
+	UpstreamHashBySubset     Annotation = "nginx.ingress.kubernetes.io/upstream-hash-by-subset"
+	UpstreamHashBySubsetSize Annotation = "nginx.ingress.kubernetes.io/upstream-hash-by-subset-size"
+	LoadBalance              Annotation = "nginx.ingress.kubernetes.io/load-balance"
+	Affinity                 Annotation = "nginx.ingress.kubernetes.io/affinity"
+	AffinityMode             Annotation = "nginx.ingress.kubernetes.io/affinity-mode"
+	SessionCookieName        Annotation = "nginx.ingress.kubernetes.io/session-cookie-name"
+	SessionCookiePath        Annotation = "nginx.ingress.kubernetes.io/session-cookie-path"
+	SessionCookieDomain      Annotation = "nginx.ingress.kubernetes.io/session-cookie-domain"
+	SessionCookieExpires     Annotation = "nginx.ingress.kubernetes.io/session-cookie-expires"
+	SessionCookieMaxAge      Annotation = "nginx.ingress.kubernetes.io/session-cookie-max-age"
+	SessionCookieSecure      Annotation = "nginx.ingress.kubernetes.io/session-cookie-secure"
+	SessionCookieSameSite    Annotation = "nginx.ingress.kubernetes.io/session-cookie-samesite"
 	ProxyRedirectFrom        Annotation = "nginx.ingress.kubernetes.io/proxy-redirect-from"
 	ProxyRedirectTo          Annotation = "nginx.ingress.kubernetes.io/proxy-redirect-to"
 	ProxyCookiePath          Annotation = "nginx.ingress.kubernetes.io/proxy-cookie-path"
@@ -39,16 +83,51 @@ const (
 	UseRegex                 Annotation = "nginx.ingress.kubernetes.io/use-regex"
 	ClientHeaderBufferSize   Annotation = "nginx.ingress.kubernetes.io/client-header-buffer-size"
 	LargeClientHeaderBuffers Annotation = "nginx.ingress.kubernetes.io/large-client-header-buffers"
+	PermanentRedirect        Annotation = "nginx.ingress.kubernetes.io/permanent-redirect"
+	PermanentRedirectCode    Annotation = "nginx.ingress.kubernetes.io/permanent-redirect-code"
+	TemporalRedirect         Annotation = "nginx.ingress.kubernetes.io/temporal-redirect"
+	Satisfy                  Annotation = "nginx.ingress.kubernetes.io/satisfy"
+	CustomHTTPErrors         Annotation = "nginx.ingress.kubernetes.io/custom-http-errors"
+	DefaultBackend           Annotation = "nginx.ingress.kubernetes.io/default-backend"
+	WhitelistSourceRange     Annotation = "nginx.ingress.kubernetes.io/whitelist-source-range"
+	ProxyConnectTimeout      Annotation = "nginx.ingress.kubernetes.io/proxy-connect-timeout"
+	ProxyReadTimeout         Annotation = "nginx.ingress.kubernetes.io/proxy-read-timeout"
+	ProxySendTimeout         Annotation = "nginx.ingress.kubernetes.io/proxy-send-timeout"
+	ProxyNextUpstream        Annotation = "nginx.ingress.kubernetes.io/proxy-next-upstream"
+	ProxyNextUpstreamTries   Annotation = "nginx.ingress.kubernetes.io/proxy-next-upstream-tries"
+	ProxyNextUpstreamTimeout Annotation = "nginx.ingress.kubernetes.io/proxy-next-upstream-timeout"
+	AppRoot                  Annotation = "nginx.ingress.kubernetes.io/app-root"
+	Canary                   Annotation = "nginx.ingress.kubernetes.io/canary"
+	CanaryWeight             Annotation = "nginx.ingress.kubernetes.io/canary-weight"
+	CanaryWeightTotal        Annotation = "nginx.ingress.kubernetes.io/canary-weight-total"
+	CanaryByHeader           Annotation = "nginx.ingress.kubernetes.io/canary-by-header"
+	CanaryByHeaderValue      Annotation = "nginx.ingress.kubernetes.io/canary-by-header-value"
+	CanaryByHeaderPattern    Annotation = "nginx.ingress.kubernetes.io/canary-by-header-pattern"
+	CanaryByCookie           Annotation = "nginx.ingress.kubernetes.io/canary-by-cookie"
+	MirrorTarget             Annotation = "nginx.ingress.kubernetes.io/mirror-target"
+	MirrorHost               Annotation = "nginx.ingress.kubernetes.io/mirror-host"
+	MirrorRequestBody        Annotation = "nginx.ingress.kubernetes.io/mirror-request-body"
 )
 
 var AllAnnotations = []Annotation{
 	AuthType,
 	AuthSecret,
 	AuthRealm,
+	AuthSecretType,
 	AuthTLSVerifyClient,
 	AuthTLSSecret,
+	AuthTLSVerifyDepth,
+	AuthTLSPassCertificate,
 	AuthURL,
+	AuthSignin,
+	AuthMethod,
+	AuthResponseHeaders,
+	AuthRequestRedirect,
+	AuthProxySetHeaders,
+	AuthSnippet,
+	EnableGlobalAuth,
 	ProxyBodySize,
+	ClientBodyBufferSize,
 	ConfigurationSnippet,
 	EnableCORS,
 	CorsAllowOrigin,
@@ -62,14 +141,47 @@ var AllAnnotations = []Annotation{
 	EnableOpentracing,
 	EnableOpentelemetry,
 	BackendProtocol,
+	ProxyHTTPVersion,
+	ProxySSLSecret,
+	ProxySSLVerify,
+	ProxySSLVerifyDepth,
+	ProxySSLName,
+	ProxySSLServerName,
+	ProxySSLCiphers,
+	ProxySSLProtocols,
+	UpstreamKeepalive,
+	UpstreamKeepaliveTimeout,
+	UpstreamKeepaliveTime,
+	UpstreamKeepaliveReqs,
 	GrpcBackend,
 	ProxyBufferSize,
 	LimitRPS,
+	LimitRPM,
+	LimitWhitelist,
+	GlobalRateLimit,
+	GlobalRateLimitWindow,
+	GlobalRateLimitKey,
 	LimitBurstMultiplier,
 	RewriteTarget,
+	PreserveTrailingSlash,
+	UsePortInRedirects,
 	SSLRedirect,
 	ForceSSLRedirect,
 	UpstreamVhost,
+	ConnectionProxyHeader,
+	UpstreamHashBy,
+	UpstreamHashBySubset,
+	UpstreamHashBySubsetSize,
+	LoadBalance,
+	Affinity,
+	AffinityMode,
+	SessionCookieName,
+	SessionCookiePath,
+	SessionCookieDomain,
+	SessionCookieExpires,
+	SessionCookieMaxAge,
+	SessionCookieSecure,
+	SessionCookieSameSite,
 	ProxyRedirectFrom,
 	ProxyRedirectTo,
 	ProxyCookiePath,
@@ -78,6 +190,30 @@ var AllAnnotations = []Annotation{
 	UseRegex,
 	ClientHeaderBufferSize,
 	LargeClientHeaderBuffers,
+	PermanentRedirect,
+	PermanentRedirectCode,
+	TemporalRedirect,
+	Satisfy,
+	CustomHTTPErrors,
+	DefaultBackend,
+	WhitelistSourceRange,
+	ProxyConnectTimeout,
+	ProxyReadTimeout,
+	ProxySendTimeout,
+	ProxyNextUpstream,
+	ProxyNextUpstreamTries,
+	ProxyNextUpstreamTimeout,
+	AppRoot,
+	Canary,
+	CanaryWeight,
+	CanaryWeightTotal,
+	CanaryByHeader,
+	CanaryByHeaderValue,
+	CanaryByHeaderPattern,
+	CanaryByCookie,
+	MirrorTarget,
+	MirrorHost,
+	MirrorRequestBody,
 }
 
 func (a Annotation) String() string {
@@ -1,6 +1,9 @@
 package tls
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
 	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
 )
@@ -9,6 +12,7 @@ import (
 // Annotations:
 //   - "nginx.ingress.kubernetes.io/auth-tls-verify-client"
 //   - "nginx.ingress.kubernetes.io/auth-tls-secret"
+//   - "nginx.ingress.kubernetes.io/auth-tls-verify-depth"
 func HandleAuthTLSVerifyClient(ctx configs.Context) {
 	verify := ctx.Annotations[string(models.AuthTLSVerifyClient)]
 	if verify == "" || verify == "off" || verify == "false" {
@@ -47,5 +51,43 @@ func HandleAuthTLSVerifyClient(ctx configs.Context) {
 		return
 	}
 
+	// TLSOptions only read secrets of their own namespace.
+	if namespace, name, found := strings.Cut(secret, "/"); found {
+		if namespace != ctx.Namespace {
+			msg := fmt.Sprintf("auth-tls-secret %q is in namespace %q, copy the secret %q into the namespace %q of the TLSOption",
+				secret, namespace, name, ctx.Namespace)
+
+			ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+			ctx.ReportWarning(string(models.AuthTLSSecret), msg)
+		} else {
+			ctx.ReportConverted(string(models.AuthTLSSecret))
+		}
+
+		secret = name
+	} else {
+		ctx.ReportConverted(string(models.AuthTLSSecret))
+	}
+
 	emitTLSOption(ctx, secret, clientAuthType)
+
+	ctx.ReportConverted(string(models.AuthTLSVerifyClient))
+
+	handleAuthTLSVerifyDepth(ctx)
+}
+
+// handleAuthTLSVerifyDepth reports auth-tls-verify-depth, Traefik always verifies
+// the complete client certificate chain against the CA of the TLSOption.
+func handleAuthTLSVerifyDepth(ctx configs.Context) {
+	ann := string(models.AuthTLSVerifyDepth)
+
+	depth, ok := ctx.Annotations[ann]
+	if !ok {
+		return
+	}
+
+	msg := fmt.Sprintf("auth-tls-verify-depth %q cannot be configured in Traefik, "+
+		"the complete client certificate chain is verified", depth)
+
+	ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+	ctx.ReportWarning(ann, msg)
 }
@@ -1,6 +1,10 @@
 package tls
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+
 	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
 	traefik "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -35,21 +39,108 @@ func emitTLSOption(ctx configs.Context, secretName, clientAuthType string) {
 
 	ctx.Result.Warnings = append(ctx.Result.Warnings,
 		"auth-tls-secret must contain CA certificates only; server cert secrets cannot be reused",
-		"CA certificates must be mounted into Traefik via static configuration",
 	)
 }
 
-// ApplyTLSOption applies TLS configs to ingress routes.
-func ApplyTLSOption(ingressRoute *traefik.IngressRoute, ctx configs.Context, scheme string) {
-	if scheme != "https" {
-		return
+// ApplyTLSOption references the client authentication TLSOption of the ingress
+// from the IngressRoute. Client certificates are verified during the TLS handshake,
+// so the routers are served on the websecure entryPoint with the ingress certificates
+// instead of the web entryPoint, where a TLS router cannot accept plain HTTP. An IngressRoute references a single certificate secret, the routes
+// of the hosts of every further TLS entry are moved to an IngressRoute of their own
+// which is returned, Traefik then selects the certificate by SNI.
+func ApplyTLSOption(ingressRoute *traefik.IngressRoute, ctx configs.Context) []*traefik.IngressRoute {
+	opt, ok := ctx.Result.TLSOptionRefs[ctx.IngressName]
+	if !ok {
+		return nil
 	}
 
-	if opt, ok := ctx.Result.TLSOptionRefs[ctx.IngressName]; ok {
-		ingressRoute.Spec.TLS = &traefik.TLS{
+	ingressRoute.Spec.EntryPoints = slices.DeleteFunc(ingressRoute.Spec.EntryPoints, func(entryPoint string) bool {
+		return entryPoint == "web"
+	})
+
+	if !slices.Contains(ingressRoute.Spec.EntryPoints, "websecure") {
+		ingressRoute.Spec.EntryPoints = append(ingressRoute.Spec.EntryPoints, "websecure")
+	}
+
+	secrets := make([]string, 0)
+	groups := make(map[string][]traefik.Route)
+
+	for _, route := range ingressRoute.Spec.Routes {
+		secret := secretOf(ctx, routeHost(route.Match))
+
+		// routes without a certificate of their own stay on the first IngressRoute.
+		if secret == "" {
+			secret = firstSecret(ctx)
+			if len(secrets) > 0 {
+				secret = secrets[0]
+			}
+		}
+
+		if _, seen := groups[secret]; !seen {
+			secrets = append(secrets, secret)
+		}
+
+		groups[secret] = append(groups[secret], route)
+	}
+
+	ingressRoutes := make([]*traefik.IngressRoute, 0)
+
+	for index, secret := range secrets {
+		target := ingressRoute
+		if index > 0 {
+			target = ingressRoute.DeepCopy()
+			target.Name = fmt.Sprintf("%s-%s", ingressRoute.Name, secret)
+
+			ingressRoutes = append(ingressRoutes, target)
+		}
+
+		target.Spec.Routes = groups[secret]
+		target.Spec.TLS = &traefik.TLS{
 			Options: &traefik.TLSOptionRef{
 				Name: opt,
 			},
+			SecretName: secret,
+		}
+	}
+
+	return ingressRoutes
+}
+
+// secretOf returns the certificate secret of the first TLS entry of the ingress
+// listing the host, empty when no entry covers it.
+func secretOf(ctx configs.Context, host string) string {
+	if host == "" {
+		return ""
+	}
+
+	for _, ingressTLS := range ctx.Ingress.Spec.TLS {
+		if ingressTLS.SecretName != "" && slices.Contains(ingressTLS.Hosts, host) {
+			return ingressTLS.SecretName
 		}
 	}
+
+	return ""
+}
+
+// firstSecret returns the first certificate secret of the ingress.
+func firstSecret(ctx configs.Context) string {
+	for _, ingressTLS := range ctx.Ingress.Spec.TLS {
+		if ingressTLS.SecretName != "" {
+			return ingressTLS.SecretName
+		}
+	}
+
+	return ""
+}
+
+// routeHost returns the host of a route rule starting with a Host matcher.
+func routeHost(match string) string {
+	rest, ok := strings.CutPrefix(match, "Host(`")
+	if !ok {
+		return ""
+	}
+
+	host, _, _ := strings.Cut(rest, "`)")
+
+	return host
 }
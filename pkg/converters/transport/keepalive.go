@@ -0,0 +1,103 @@
+package transport
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/duration"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// HandleUpstreamKeepalive handles the below annotations.
+// Annotations:
+//   - "nginx.ingress.kubernetes.io/upstream-keepalive-connections"
+//   - "nginx.ingress.kubernetes.io/upstream-keepalive-timeout"
+//   - "nginx.ingress.kubernetes.io/upstream-keepalive-time"
+//   - "nginx.ingress.kubernetes.io/upstream-keepalive-requests"
+//
+// ingress-nginx reads these from its ConfigMap, they are honoured on the Ingress as
+// well so that the connection pool towards the backend survives the migration.
+func HandleUpstreamKeepalive(ctx configs.Context) {
+	handleKeepaliveConnections(ctx)
+
+	handleKeepaliveTimeout(ctx)
+
+	for _, annotation := range []models.Annotation{models.UpstreamKeepaliveTime, models.UpstreamKeepaliveReqs} {
+		ann := string(annotation)
+
+		val, ok := ctx.Annotations[ann]
+		if !ok {
+			continue
+		}
+
+		msg := fmt.Sprintf("%s %q cannot be configured in Traefik, backend connections are reused "+
+			"until they are idle for the idleConnTimeout of the ServersTransport",
+			strings.TrimPrefix(ann, "nginx.ingress.kubernetes.io/"), val)
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportSkipped(ann, msg)
+	}
+}
+
+// handleKeepaliveConnections maps the idle connections NGINX keeps per upstream onto
+// maxIdleConnsPerHost, 0 disables the connection pool in both.
+func handleKeepaliveConnections(ctx configs.Context) {
+	ann := string(models.UpstreamKeepalive)
+
+	val, ok := ctx.Annotations[ann]
+	if !ok {
+		return
+	}
+
+	connections, err := strconv.Atoi(strings.TrimSpace(val))
+	if err != nil || connections < 0 {
+		msg := fmt.Sprintf("upstream-keepalive-connections has an invalid value %q and was not converted", val)
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportSkipped(ann, msg)
+
+		return
+	}
+
+	if connections == 0 {
+		// a negative value disables the idle connections of the ServersTransport.
+		connections = -1
+	}
+
+	serversTransport(ctx).Spec.MaxIdleConnsPerHost = connections
+
+	msg := "upstream-keepalive-connections limits the idle connections per NGINX worker and upstream, " +
+		"Traefik applies maxIdleConnsPerHost per backend server of the Traefik instance"
+
+	ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+	ctx.ReportWarning(ann, msg)
+}
+
+// handleKeepaliveTimeout maps the time NGINX keeps idle upstream connections open
+// onto the idleConnTimeout of the ServersTransport.
+func handleKeepaliveTimeout(ctx configs.Context) {
+	ann := string(models.UpstreamKeepaliveTimeout)
+
+	val, ok := ctx.Annotations[ann]
+	if !ok {
+		return
+	}
+
+	timeout, err := duration.Parse(val)
+	if err != nil || timeout <= 0 {
+		msg := fmt.Sprintf("upstream-keepalive-timeout has an invalid value %q and was not converted", val)
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportSkipped(ann, msg)
+
+		return
+	}
+
+	idle := intstr.FromString(duration.Format(timeout))
+	ForwardingTimeouts(ctx).IdleConnTimeout = &idle
+
+	ctx.ReportConverted(ann)
+}
@@ -0,0 +1,59 @@
+package transport
+
+import (
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+)
+
+// HandleProxyHTTPVersion handles the below annotations.
+// Annotations:
+//   - "nginx.ingress.kubernetes.io/proxy-http-version"
+//
+// Traefik negotiates HTTP/2 with TLS backends, forcing HTTP/1.x disables HTTP/2 on
+// the ServersTransport of the ingress.
+func HandleProxyHTTPVersion(ctx configs.Context) {
+	ann := string(models.ProxyHTTPVersion)
+
+	version, ok := ctx.Annotations[ann]
+	if !ok {
+		return
+	}
+
+	switch strings.TrimSpace(version) {
+	case "1.1":
+		serversTransport(ctx).Spec.DisableHTTP2 = true
+
+		ctx.ReportConverted(ann)
+	case "1.0":
+		serversTransport(ctx).Spec.DisableHTTP2 = true
+
+		msg := "proxy-http-version 1.0 cannot be forced in Traefik, HTTP/2 was disabled on the ServersTransport " +
+			"and the backend is reached over HTTP/1.1"
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportWarning(ann, msg)
+	default:
+		msg := "unsupported value for proxy-http-version: " + version + " (expected 1.0 or 1.1)"
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportSkipped(ann, msg)
+
+		return
+	}
+
+	if isH2CBackend(ctx) {
+		msg := "proxy-http-version " + version + " disables HTTP/2 but the backend-protocol requires h2c, " +
+			"the ServersTransport setting does not apply to h2c backends"
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+	}
+}
+
+// isH2CBackend reports whether the backend is reached over cleartext HTTP/2 (gRPC).
+func isH2CBackend(ctx configs.Context) bool {
+	protocol := strings.ToUpper(ctx.Annotations[string(models.BackendProtocol)])
+
+	return protocol == "GRPC" || (protocol == "" && ctx.Annotations[string(models.GrpcBackend)] == "true")
+}
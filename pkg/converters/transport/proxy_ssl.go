@@ -0,0 +1,236 @@
+package transport
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/converters/models"
+	traefik "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+)
+
+// clientCertificateKeys are the keys Traefik reads the client certificate of a ServersTransport from.
+var clientCertificateKeys = []string{"tls.crt", "tls.key"}
+
+// HandleProxySSL handles the below annotations.
+// Annotations:
+//   - "nginx.ingress.kubernetes.io/proxy-ssl-secret"
+//   - "nginx.ingress.kubernetes.io/proxy-ssl-verify"
+//   - "nginx.ingress.kubernetes.io/proxy-ssl-verify-depth"
+//   - "nginx.ingress.kubernetes.io/proxy-ssl-name"
+//   - "nginx.ingress.kubernetes.io/proxy-ssl-server-name"
+//   - "nginx.ingress.kubernetes.io/proxy-ssl-ciphers"
+//   - "nginx.ingress.kubernetes.io/proxy-ssl-protocols"
+func HandleProxySSL(ctx configs.Context) {
+	secret := handleProxySSLSecret(ctx)
+
+	handleProxySSLVerify(ctx, secret)
+
+	handleProxySSLName(ctx)
+
+	handleProxySSLUnsupported(ctx)
+}
+
+// handleProxySSLSecret presents the client certificate of proxy-ssl-secret to TLS
+// backends through the certificatesSecrets of the ServersTransport. It returns the
+// name of the referenced secret, empty when the annotation is not set.
+func handleProxySSLSecret(ctx configs.Context) string {
+	ann := string(models.ProxySSLSecret)
+
+	val, ok := ctx.Annotations[ann]
+	if !ok || strings.TrimSpace(val) == "" {
+		return ""
+	}
+
+	secret, warnings := proxySSLSecretName(ctx, strings.TrimSpace(val))
+
+	stSpec := &serversTransport(ctx).Spec
+	if !slices.Contains(stSpec.CertificatesSecrets, secret) {
+		stSpec.CertificatesSecrets = append(stSpec.CertificatesSecrets, secret)
+	}
+
+	if !isTLSBackend(ctx) {
+		warnings = append(warnings, "proxy-ssl-secret only applies to TLS backends, "+
+			"set backend-protocol to HTTPS or GRPCS for the client certificate to be presented")
+	}
+
+	if len(warnings) == 0 {
+		ctx.ReportConverted(ann)
+
+		return secret
+	}
+
+	msg := strings.Join(warnings, "; ")
+
+	ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+	ctx.ReportWarning(ann, msg)
+
+	return secret
+}
+
+// handleProxySSLVerify maps proxy-ssl-verify onto the certificate verification of the
+// ServersTransport. NGINX verifies against the ca.crt of proxy-ssl-secret, which
+// Traefik reads from the same secret through rootCAs.
+func handleProxySSLVerify(ctx configs.Context, secret string) {
+	ann := string(models.ProxySSLVerify)
+
+	if verify, ok := ctx.Annotations[ann]; ok {
+		switch strings.TrimSpace(verify) {
+		case "on":
+			if secret == "" {
+				msg := "proxy-ssl-verify is on but proxy-ssl-secret is missing, " +
+					"Traefik verifies the backend certificate against the system CAs"
+
+				ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+				ctx.ReportWarning(ann, msg)
+
+				break
+			}
+
+			stSpec := &serversTransport(ctx).Spec
+			stSpec.RootCAs = append(stSpec.RootCAs, traefik.RootCA{Secret: &secret})
+
+			ctx.ReportConverted(ann)
+		case "off":
+			serversTransport(ctx).Spec.InsecureSkipVerify = true
+
+			ctx.ReportConverted(ann)
+		default:
+			msg := "unsupported value for proxy-ssl-verify: " + verify + " (expected on or off)"
+
+			ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+			ctx.ReportSkipped(ann, msg)
+		}
+	}
+
+	annDepth := string(models.ProxySSLVerifyDepth)
+
+	if depth, ok := ctx.Annotations[annDepth]; ok {
+		msg := fmt.Sprintf("proxy-ssl-verify-depth %q cannot be configured in Traefik, "+
+			"the complete backend certificate chain is verified", depth)
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportWarning(annDepth, msg)
+	}
+}
+
+// handleProxySSLName maps proxy-ssl-name and proxy-ssl-server-name onto the serverName
+// of the ServersTransport, which Traefik uses for both SNI and verification.
+func handleProxySSLName(ctx configs.Context) {
+	annName := string(models.ProxySSLName)
+	annServerName := string(models.ProxySSLServerName)
+
+	name, hasName := ctx.Annotations[annName]
+	serverName, hasServerName := ctx.Annotations[annServerName]
+
+	name = strings.TrimSpace(name)
+
+	if hasName && name != "" {
+		serversTransport(ctx).Spec.ServerName = name
+
+		ctx.ReportConverted(annName)
+	}
+
+	if !hasServerName {
+		return
+	}
+
+	switch strings.TrimSpace(serverName) {
+	case "on":
+		if name != "" {
+			ctx.ReportConverted(annServerName)
+
+			return
+		}
+
+		msg := "proxy-ssl-server-name is on without proxy-ssl-name, Traefik only sends SNI for backend hostnames, " +
+			"set proxy-ssl-name to the name expected by the backend"
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportWarning(annServerName, msg)
+	case "off":
+		if name == "" {
+			ctx.ReportIgnored(annServerName, "no serverName is set on the ServersTransport")
+
+			return
+		}
+
+		msg := "proxy-ssl-server-name is off but Traefik sends the serverName " + name + " as SNI to the backend"
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportWarning(annServerName, msg)
+	default:
+		msg := "unsupported value for proxy-ssl-server-name: " + serverName + " (expected on or off)"
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportSkipped(annServerName, msg)
+	}
+}
+
+// handleProxySSLUnsupported warns about the backend TLS settings a ServersTransport cannot express.
+func handleProxySSLUnsupported(ctx configs.Context) {
+	for _, annotation := range []models.Annotation{models.ProxySSLCiphers, models.ProxySSLProtocols} {
+		ann := string(annotation)
+
+		val, ok := ctx.Annotations[ann]
+		if !ok {
+			continue
+		}
+
+		msg := fmt.Sprintf("%s %q cannot be configured in Traefik, a ServersTransport does not pin "+
+			"the ciphers or protocol versions used towards the backend", strings.TrimPrefix(ann, "nginx.ingress.kubernetes.io/"), val)
+
+		ctx.Result.Warnings = append(ctx.Result.Warnings, msg)
+		ctx.ReportSkipped(ann, msg)
+	}
+}
+
+// proxySSLSecretName resolves the namespace/name value of proxy-ssl-secret to the name
+// referenced by the ServersTransport, which only reads secrets of its own namespace.
+// In cluster mode the secret is validated to hold a client certificate.
+func proxySSLSecretName(ctx configs.Context, val string) (string, []string) {
+	warnings := make([]string, 0)
+
+	namespace, name, found := strings.Cut(val, "/")
+	if !found {
+		namespace, name = ctx.Namespace, namespace
+	}
+
+	if namespace != ctx.Namespace {
+		warnings = append(warnings, fmt.Sprintf(
+			"proxy-ssl-secret %q is in namespace %q, copy the secret %q into the namespace %q of the ServersTransport",
+			val, namespace, name, ctx.Namespace))
+	}
+
+	if ctx.Options.Secrets == nil {
+		return name, warnings
+	}
+
+	keys, err := ctx.Options.Secrets(namespace, name)
+	if err != nil {
+		return name, append(warnings, fmt.Sprintf("reading proxy-ssl-secret %s/%s failed: %s", namespace, name, err.Error()))
+	}
+
+	missing := make([]string, 0)
+
+	for _, key := range clientCertificateKeys {
+		if !slices.Contains(keys, key) {
+			missing = append(missing, key)
+		}
+	}
+
+	if len(missing) > 0 {
+		warnings = append(warnings, fmt.Sprintf("proxy-ssl-secret %s/%s has no %s, Traefik cannot load the client certificate",
+			namespace, name, strings.Join(missing, ", ")))
+	}
+
+	return name, warnings
+}
+
+// isTLSBackend reports whether the backend is reached over TLS.
+func isTLSBackend(ctx configs.Context) bool {
+	protocol := strings.ToUpper(ctx.Annotations[string(models.BackendProtocol)])
+
+	return protocol == "HTTPS" || protocol == "GRPCS"
+}
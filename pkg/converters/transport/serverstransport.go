@@ -0,0 +1,67 @@
+package transport
+
+import (
+	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
+	traefik "github.com/traefik/traefik/v3/pkg/provider/kubernetes/crd/traefikio/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// serversTransport returns the ServersTransport of the ingress, creating it on first
+// use so that every upstream annotation configures the same object.
+func serversTransport(ctx configs.Context) *traefik.ServersTransport {
+	if ctx.Result.ServersTransportRefs == nil {
+		ctx.Result.ServersTransportRefs = make(map[string]string)
+	}
+
+	name := ctx.IngressName + "-transport"
+
+	for _, serversTransport := range ctx.Result.ServersTransports {
+		if serversTransport.GetName() == name {
+			return serversTransport
+		}
+	}
+
+	serversTransport := &traefik.ServersTransport{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: traefik.SchemeGroupVersion.String(),
+			Kind:       "ServersTransport",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ctx.Namespace,
+		},
+	}
+
+	ctx.Result.ServersTransports = append(ctx.Result.ServersTransports, serversTransport)
+	ctx.Result.ServersTransportRefs[ctx.IngressName] = name
+
+	return serversTransport
+}
+
+// ForwardingTimeouts returns the forwarding timeouts of the ServersTransport of the
+// ingress, creating both on first use.
+func ForwardingTimeouts(ctx configs.Context) *traefik.ForwardingTimeouts {
+	stSpec := &serversTransport(ctx).Spec
+	if stSpec.ForwardingTimeouts == nil {
+		stSpec.ForwardingTimeouts = &traefik.ForwardingTimeouts{}
+	}
+
+	return stSpec.ForwardingTimeouts
+}
+
+// ApplyServersTransport references the ServersTransport of the ingress from every
+// service of the IngressRoute.
+func ApplyServersTransport(ingressRoute *traefik.IngressRoute, ctx configs.Context) {
+	name, ok := ctx.Result.ServersTransportRefs[ctx.IngressName]
+	if !ok {
+		return
+	}
+
+	for routeIndex := range ingressRoute.Spec.Routes {
+		services := ingressRoute.Spec.Routes[routeIndex].Services
+
+		for serviceIndex := range services {
+			services[serviceIndex].ServersTransport = name
+		}
+	}
+}
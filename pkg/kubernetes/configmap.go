@@ -0,0 +1,17 @@
+package kubernetes
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetConfigMapData returns the data of the ConfigMap with the given name from the specified namespace.
+func (cfg *Config) GetConfigMapData(namespace, name string) (map[string]string, error) {
+	configMap, err := cfg.clientSet.CoreV1().ConfigMaps(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return configMap.Data, nil
+}
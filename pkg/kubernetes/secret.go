@@ -0,0 +1,26 @@
+package kubernetes
+
+import (
+	"context"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetSecretKeys returns the sorted data keys of the Secret with the given name from the specified namespace.
+func (cfg *Config) GetSecretKeys(namespace, name string) ([]string, error) {
+	secret, err := cfg.clientSet.CoreV1().Secrets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(secret.Data))
+
+	for key := range secret.Data {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys, nil
+}
@@ -29,6 +29,9 @@ type SummaryCounts struct {
 
 	// Ignored is the number of annotations that were intentionally ignored.
 	Ignored int `yaml:"ignored,omitempty"   json:"ignored,omitempty"`
+
+	// Errors is the number of annotations escalated to errors in strict mode.
+	Errors int `yaml:"errors,omitempty"    json:"errors,omitempty"`
 }
 
 // Config controls how reports are rendered.
@@ -44,6 +47,7 @@ var statusLabel = map[configs.AnnotationStatus]string{
 	configs.AnnotationWarned:    "Warning",
 	configs.AnnotationSkipped:   "Skipped",
 	configs.AnnotationIgnored:   "Ignored",
+	configs.AnnotationError:     "Error",
 }
 
 const fixedStringLength = 80
@@ -150,6 +154,7 @@ func renderSummaryTable(summaryCounts SummaryCounts) error {
 		{"Warnings", color.HiYellowString(strconv.Itoa(summaryCounts.Warnings))},
 		{"Skipped", color.HiRedString(strconv.Itoa(summaryCounts.Skipped))},
 		{"Ignored", color.HiBlueString(strconv.Itoa(summaryCounts.Ignored))},
+		{"Errors", color.HiRedString(strconv.Itoa(summaryCounts.Errors))},
 		{"Result", resultLabel(summaryCounts)},
 	}
 
@@ -176,6 +181,8 @@ func (cfg *Config) printIngressReport(ingressReport configs.IngressReport) {
 			fmt.Printf("  ❌ %s\n      → %s\n", entries.Name, entries.Message)
 		case configs.AnnotationIgnored:
 			fmt.Printf("  ℹ️  %s\n", entries.Name)
+		case configs.AnnotationError:
+			fmt.Printf("  ⛔ %s\n      → %s\n", entries.Name, entries.Message)
 		}
 	}
 
@@ -199,6 +206,7 @@ func printSummaryText(title string, summaryCounts SummaryCounts) {
 	fmt.Printf("Warnings:  %s\n", color.HiYellowString(strconv.Itoa(summaryCounts.Warnings)))
 	fmt.Printf("Skipped:   %s\n", color.HiRedString(strconv.Itoa(summaryCounts.Skipped)))
 	fmt.Printf("Ignored:   %s\n", color.HiBlueString(strconv.Itoa(summaryCounts.Ignored)))
+	fmt.Printf("Errors:    %s\n", color.HiRedString(strconv.Itoa(summaryCounts.Errors)))
 	fmt.Printf("Result:    %s\n\n", resultLabel(summaryCounts))
 }
 
@@ -206,6 +214,10 @@ func printSummaryText(title string, summaryCounts SummaryCounts) {
 
 // resultLabel returns a human-readable overall result string based on summary counts.
 func resultLabel(summaryCounts SummaryCounts) string {
+	if summaryCounts.Errors > 0 {
+		return color.HiRedString("Failed (strict mode)")
+	}
+
 	if summaryCounts.Skipped > 0 {
 		return color.HiRedString("Manual action required")
 	}
@@ -231,6 +243,8 @@ func summarizeIngress(ingressReport configs.IngressReport) SummaryCounts {
 			summaryCounts.Skipped++
 		case configs.AnnotationIgnored:
 			summaryCounts.Ignored++
+		case configs.AnnotationError:
+			summaryCounts.Errors++
 		}
 	}
 
@@ -248,6 +262,7 @@ func summarizeGlobal(globalReport configs.GlobalReport) SummaryCounts {
 		total.Warnings += summarizedIngress.Warnings
 		total.Skipped += summarizedIngress.Skipped
 		total.Ignored += summarizedIngress.Ignored
+		total.Errors += summarizedIngress.Errors
 	}
 
 	return total
@@ -264,6 +279,8 @@ func statusLabelColored(annotationStatus configs.AnnotationStatus) string {
 		return color.HiRedString("Skipped")
 	case configs.AnnotationIgnored:
 		return color.HiBlueString("Ignored")
+	case configs.AnnotationError:
+		return color.HiRedString("Error")
 	default:
 		return statusLabel[annotationStatus]
 	}
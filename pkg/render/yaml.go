@@ -5,39 +5,43 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/nikhilsbhat/nginx-traefik-converter/pkg/configs"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
 )
 
-const dirPermission = 0o755
+const (
+	dirPermission  = 0o755
+	singleFileName = "traefik.yaml"
+)
 
 // WriteYAML writes the translated inputs to respective files.
-func WriteYAML(res configs.Result, outDir string) error {
+// By default every object kind is written to its own file (middlewares.yaml,
+// ingressroutes.yaml, ...), when singleFile is set all of them are written to traefik.yaml.
+func WriteYAML(res configs.Result, outDir string, singleFile bool) error {
 	if err := os.MkdirAll(outDir, dirPermission); err != nil {
 		return err
 	}
 
-	if err := writeObjects(
-		filepath.Join(outDir, "middlewares.yaml"),
-		toClientObjects(res.Middlewares),
-	); err != nil {
-		return err
-	}
+	groups := res.ObjectsByKind()
 
-	if err := writeObjects(
-		filepath.Join(outDir, "ingressroutes.yaml"),
-		toClientObjects(res.IngressRoutes),
-	); err != nil {
-		return err
-	}
+	if singleFile {
+		objects := make([]client.Object, 0)
+		for _, group := range groups {
+			objects = append(objects, group.Objects...)
+		}
 
-	if err := writeObjects(
-		filepath.Join(outDir, "tlsoptions.yaml"),
-		toClientObjects(res.TLSOptions),
-	); err != nil {
-		return err
+		if err := writeObjects(filepath.Join(outDir, singleFileName), objects); err != nil {
+			return err
+		}
+	} else {
+		for _, group := range groups {
+			if err := writeObjects(filepath.Join(outDir, kindFileName(group.Kind)), group.Objects); err != nil {
+				return err
+			}
+		}
 	}
 
 	if len(res.Warnings) > 0 {
@@ -49,16 +53,21 @@ func WriteYAML(res configs.Result, outDir string) error {
 		}
 	}
 
+	if len(res.Notes) > 0 {
+		if err := writeWarnings(
+			filepath.Join(outDir, "notes.txt"),
+			res.Notes,
+		); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-func toClientObjects[T client.Object](in []T) []client.Object {
-	out := make([]client.Object, 0, len(in))
-	for _, o := range in {
-		out = append(out, o)
-	}
-
-	return out
+// kindFileName returns the file name objects of the given kind are written to, e.g. "middlewares.yaml".
+func kindFileName(kind string) string {
+	return strings.ToLower(kind) + "s.yaml"
 }
 
 func writeObjects(path string, objs []client.Object) error {